@@ -14,13 +14,14 @@ import (
 type AnimationType string
 
 const (
-	AnimBlink    AnimationType = "blink"    // Случайное мигание букв
-	AnimPulse    AnimationType = "pulse"    // Весь текст мигает
-	AnimWave     AnimationType = "wave"     // Волна по буквам
-	AnimTyping   AnimationType = "typing"   // Печатание по буквам
-	AnimGlitch   AnimationType = "glitch"   // Глитч-эффект
-	AnimSequence AnimationType = "sequence" // Последовательное мигание
-	AnimRandom   AnimationType = "random"   // Случайная смена анимаций
+	AnimBlink     AnimationType = "blink"     // Случайное мигание букв
+	AnimPulse     AnimationType = "pulse"     // Весь текст мигает
+	AnimWave      AnimationType = "wave"      // Волна по буквам
+	AnimTyping    AnimationType = "typing"    // Печатание по буквам
+	AnimGlitch    AnimationType = "glitch"    // Глитч-эффект
+	AnimSequence  AnimationType = "sequence"  // Последовательное мигание
+	AnimHighlight AnimationType = "highlight" // Случайная подсветка букв
+	AnimRandom    AnimationType = "random"    // Случайная смена анимаций
 )
 
 // AnimConfig общие настройки анимации
@@ -41,6 +42,8 @@ type AnimConfig struct {
 	WaveWidth int
 	// RandomSwitchFrames сколько кадров до смены анимации в random режиме
 	RandomSwitchFrames int
+	// HighlightStyle стиль подсвеченных букв
+	HighlightStyle Style
 }
 
 // DefaultAnimConfig возвращает настройки по умолчанию
@@ -54,6 +57,7 @@ func DefaultAnimConfig() AnimConfig {
 		GlitchChars:        "░▒▓█▄▀■□●○",
 		WaveWidth:          3,
 		RandomSwitchFrames: 30,
+		HighlightStyle:     Style{Foreground: ColorBrightWhite, Bold: true},
 	}
 }
 
@@ -85,7 +89,7 @@ func NewAnimation(text string) *Animation {
 // NewAnimationWithConfig создаёт анимацию с настройками
 func NewAnimationWithConfig(text string, fontConfig Config, animConfig AnimConfig) *Animation {
 	return &Animation{
-		text:      strings.ToUpper(text),
+		text:      text,
 		blockFont: NewWithConfig(fontConfig),
 		config:    animConfig,
 		stopChan:  make(chan struct{}),
@@ -93,6 +97,17 @@ func NewAnimationWithConfig(text string, fontConfig Config, animConfig AnimConfi
 	}
 }
 
+// NewAnimationWithFont создаёт анимацию с готовым шрифтом (например, из FIGlet-файла)
+func NewAnimationWithFont(text string, bf *BlockFont, animConfig AnimConfig) *Animation {
+	return &Animation{
+		text:      text,
+		blockFont: bf,
+		config:    animConfig,
+		stopChan:  make(chan struct{}),
+		rng:       rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
 // SetType устанавливает тип анимации
 func (a *Animation) SetType(t AnimationType) {
 	a.config.Type = t
@@ -139,6 +154,8 @@ func (a *Animation) Frame() string {
 		return a.frameGlitch()
 	case AnimSequence:
 		return a.frameSequence()
+	case AnimHighlight:
+		return a.frameHighlight()
 	case AnimRandom:
 		return a.frameRandom()
 	default:
@@ -149,7 +166,7 @@ func (a *Animation) Frame() string {
 // frameRandom случайно переключает типы анимаций
 func (a *Animation) frameRandom() string {
 	// Список доступных анимаций (кроме random)
-	types := []AnimationType{AnimBlink, AnimPulse, AnimWave, AnimTyping, AnimGlitch, AnimSequence}
+	types := []AnimationType{AnimBlink, AnimPulse, AnimWave, AnimTyping, AnimGlitch, AnimSequence, AnimHighlight}
 
 	// Переключаем тип каждые N кадров
 	if a.frameCount == 1 || a.frameCount%a.config.RandomSwitchFrames == 0 {
@@ -168,6 +185,8 @@ func (a *Animation) frameRandom() string {
 		return a.frameGlitch()
 	case AnimSequence:
 		return a.frameSequence()
+	case AnimHighlight:
+		return a.frameHighlight()
 	default:
 		return a.frameBlink()
 	}
@@ -269,55 +288,49 @@ func (a *Animation) frameSequence() string {
 	return a.renderText(hidePositions, nil)
 }
 
-// renderText рендерит текст с эффектами
-func (a *Animation) renderText(hidePositions map[int]bool, glitchPositions map[int]rune) string {
-	runes := []rune(a.text)
-	height := a.blockFont.height * a.blockFont.config.Scale
-	lines := make([]string, height)
-
-	for i, ch := range runes {
-		var pattern []string
+// frameHighlight случайная подсветка букв стилем HighlightStyle
+func (a *Animation) frameHighlight() string {
+	highlightCount := a.config.Min
+	if a.config.Max > a.config.Min {
+		highlightCount += a.rng.Intn(a.config.Max - a.config.Min + 1)
+	}
 
-		if hidePositions != nil && hidePositions[i] && ch != ' ' {
-			pattern = a.getBlankPattern()
-		} else if glitchPositions != nil && glitchPositions[i] != 0 {
-			pattern = a.getGlitchPattern(glitchPositions[i])
-		} else {
-			pattern = a.getCharPattern(ch)
-		}
+	highlightPositions := make(map[int]bool)
+	textLen := len([]rune(a.text))
 
-		for lineIdx := 0; lineIdx < height; lineIdx++ {
-			patternLine := ""
-			if lineIdx < len(pattern) {
-				patternLine = pattern[lineIdx]
-			}
-			lines[lineIdx] += patternLine + strings.Repeat(a.blockFont.config.Space, a.blockFont.config.Scale)
+	for i := 0; i < highlightCount && len(highlightPositions) < textLen; i++ {
+		if a.rng.Float64() < a.config.Chance {
+			pos := a.rng.Intn(textLen)
+			highlightPositions[pos] = true
 		}
 	}
 
-	result := strings.Join(lines, "\n")
-
-	if a.blockFont.config.Color != "" {
-		result = a.blockFont.config.Color + result + ColorReset
-	}
+	return a.blockFont.RenderFunc(a.text, func(i int, ch rune) Style {
+		if highlightPositions[i] {
+			return a.config.HighlightStyle
+		}
+		return Style{}
+	})
+}
 
-	return result
+// renderText рендерит текст с эффектами
+func (a *Animation) renderText(hidePositions map[int]bool, glitchPositions map[int]rune) string {
+	// Раскладка букв считается по настоящим глифам, поэтому текст не прыгает
+	return a.blockFont.render(a.text, renderOptions{pattern: func(i int, ch rune) []string {
+		if hidePositions != nil && hidePositions[i] && ch != ' ' {
+			return a.getBlankPattern(ch)
+		} else if glitchPositions != nil && glitchPositions[i] != 0 {
+			return a.getGlitchPattern(ch, glitchPositions[i])
+		}
+		return a.getCharPattern(ch)
+	}})
 }
 
 // renderEmpty рендерит пустой текст (для пульса)
 func (a *Animation) renderEmpty() string {
-	runes := []rune(a.text)
-	height := a.blockFont.height * a.blockFont.config.Scale
-	lines := make([]string, height)
-
-	for range runes {
-		pattern := a.getBlankPattern()
-		for lineIdx := 0; lineIdx < height; lineIdx++ {
-			lines[lineIdx] += pattern[lineIdx] + strings.Repeat(a.blockFont.config.Space, a.blockFont.config.Scale)
-		}
-	}
-
-	return strings.Join(lines, "\n")
+	return a.blockFont.render(a.text, renderOptions{pattern: func(i int, ch rune) []string {
+		return a.getBlankPattern(ch)
+	}})
 }
 
 // renderFull рендерит полный текст
@@ -328,40 +341,31 @@ func (a *Animation) renderFull() string {
 
 // getCharPattern возвращает паттерн символа
 func (a *Animation) getCharPattern(ch rune) []string {
-	pattern, ok := a.blockFont.chars[ch]
-	if !ok {
-		pattern = a.blockFont.chars[' ']
-	}
+	return a.blockFont.pattern(ch)
+}
 
-	scaled := make([]string, a.blockFont.height*a.blockFont.config.Scale)
-	for i := 0; i < a.blockFont.height; i++ {
-		scaledLine := a.blockFont.scaleLine(pattern[i])
-		for s := 0; s < a.blockFont.config.Scale; s++ {
-			scaled[i*a.blockFont.config.Scale+s] = scaledLine
-		}
-	}
-	return scaled
+// getCharWidth возвращает ширину символа (без учёта масштаба)
+func (a *Animation) getCharWidth(ch rune) int {
+	return a.blockFont.width(ch)
 }
 
-// getBlankPattern возвращает пустой паттерн
-func (a *Animation) getBlankPattern() []string {
-	width := 5 * a.blockFont.config.Scale
-	blankLine := strings.Repeat(a.blockFont.config.Space, width)
+// getBlankPattern возвращает пустой паттерн шириной с символ ch
+func (a *Animation) getBlankPattern(ch rune) []string {
+	blankLine := strings.Repeat(" ", a.getCharWidth(ch))
 
-	pattern := make([]string, a.blockFont.height*a.blockFont.config.Scale)
+	pattern := make([]string, a.blockFont.font.Height())
 	for i := range pattern {
 		pattern[i] = blankLine
 	}
 	return pattern
 }
 
-// getGlitchPattern возвращает паттерн глитча
-func (a *Animation) getGlitchPattern(ch rune) []string {
-	width := 5 * a.blockFont.config.Scale
-	glitchChar := string(ch)
-	glitchLine := strings.Repeat(glitchChar, width)
+// getGlitchPattern возвращает паттерн глитча шириной с символ ch
+func (a *Animation) getGlitchPattern(ch rune, glitch rune) []string {
+	width := a.getCharWidth(ch)
+	glitchChar := string(glitch)
 
-	pattern := make([]string, a.blockFont.height*a.blockFont.config.Scale)
+	pattern := make([]string, a.blockFont.font.Height())
 	for i := range pattern {
 		// Случайное заполнение
 		line := ""
@@ -369,12 +373,11 @@ func (a *Animation) getGlitchPattern(ch rune) []string {
 			if a.rng.Float64() < 0.7 {
 				line += glitchChar
 			} else {
-				line += a.blockFont.config.Space
+				line += " "
 			}
 		}
 		pattern[i] = line
 	}
-	_ = glitchLine // подавить предупреждение
 	return pattern
 }
 
@@ -0,0 +1,109 @@
+package gofig
+
+import "unicode/utf8"
+
+// Border is the set of characters a frame around the output is drawn with.
+// The zero value draws no border.
+type Border struct {
+	TopLeft, Top, TopRight          string
+	Left, Right                     string
+	BottomLeft, Bottom, BottomRight string
+}
+
+// Standard borders
+var (
+	BorderNone    = Border{}
+	BorderSingle  = Border{"┌", "─", "┐", "│", "│", "└", "─", "┘"}
+	BorderDouble  = Border{"╔", "═", "╗", "║", "║", "╚", "═", "╝"}
+	BorderRounded = Border{"╭", "─", "╮", "│", "│", "╰", "─", "╯"}
+	BorderHeavy   = Border{"┏", "━", "┓", "┃", "┃", "┗", "━", "┛"}
+	BorderBlock   = Border{"▛", "▀", "▜", "▌", "▐", "▙", "▄", "▟"}
+)
+
+// IsZero reports whether the border draws nothing
+func (b Border) IsZero() bool {
+	return b == Border{}
+}
+
+// frame draws the border with the title around screen
+func (bf *BlockFont) frame(screen [][]termCell) [][]termCell {
+	border := bf.config.Border
+	if border.IsZero() {
+		return screen
+	}
+	style := Style{Foreground: bf.config.BorderColor}
+	piece := func(text string) termCell {
+		return termCell{text: text, style: style}
+	}
+
+	width := 0
+	if len(screen) > 0 {
+		width = columns(screen[0])
+	}
+	edge := func(left, fill, right, title string) []termCell {
+		row := make([]termCell, 0, width+2)
+		row = append(row, piece(left))
+		for i := 0; i < width; i++ {
+			row = append(row, piece(fill))
+		}
+		row = append(row, piece(right))
+		if title != "" {
+			bf.drawTitle(row[1:width+1], title)
+		}
+		return row
+	}
+
+	framed := make([][]termCell, 0, len(screen)+2)
+	framed = append(framed, edge(border.TopLeft, border.Top, border.TopRight, bf.config.Title))
+	for _, row := range screen {
+		line := append([]termCell{piece(border.Left)}, row...)
+		framed = append(framed, append(line, piece(border.Right)))
+	}
+	framed = append(framed, edge(border.BottomLeft, border.Bottom, border.BottomRight, ""))
+	return framed
+}
+
+// drawTitle writes the title into the top edge, one character per cell,
+// aligned by Config.TitleAlign and shortened to fit
+func (bf *BlockFont) drawTitle(edge []termCell, title string) {
+	runes := []rune(" " + title + " ")
+	// Keep one edge character on each side when there is room
+	room := len(edge) - 2
+	if room < 3 {
+		return
+	}
+	if len(runes) > room {
+		runes = append(runes[:room-2], '…', ' ')
+	}
+
+	start := 1
+	switch bf.config.TitleAlign {
+	case AlignCenter:
+		start = (len(edge) - len(runes)) / 2
+	case AlignRight:
+		start = len(edge) - len(runes) - 1
+	}
+	for i, r := range runes {
+		edge[start+i].text = string(r)
+	}
+}
+
+// borderWidth returns the terminal columns the border adds to a line
+func (bf *BlockFont) borderWidth() int {
+	border := bf.config.Border
+	if border.IsZero() {
+		return 0
+	}
+	return utf8.RuneCountInString(border.Left) + utf8.RuneCountInString(border.Right)
+}
+
+// SetBorder draws a border of the given color around the output
+func (bf *BlockFont) SetBorder(border Border, color Color) {
+	bf.config.Border = border
+	bf.config.BorderColor = color
+}
+
+// SetTitle sets the text shown in the top edge of the border
+func (bf *BlockFont) SetTitle(title string) {
+	bf.config.Title = title
+}
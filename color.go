@@ -0,0 +1,301 @@
+package gofig
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ColorReset resets all terminal colors and attributes
+const ColorReset = "\033[0m"
+
+// Color is a terminal color: one of the 16 standard colors, an entry of the
+// 256-color palette or a 24-bit RGB value. The zero value means no color.
+type Color uint32
+
+const (
+	colorKindMask Color = 0xff << 24
+	colorANSI     Color = 1 << 24
+	color256      Color = 2 << 24
+	colorRGB      Color = 3 << 24
+)
+
+// Standard colors
+const (
+	NoColor            Color = 0
+	ColorBlack               = colorANSI | 0
+	ColorRed                 = colorANSI | 1
+	ColorGreen               = colorANSI | 2
+	ColorYellow              = colorANSI | 3
+	ColorBlue                = colorANSI | 4
+	ColorMagenta             = colorANSI | 5
+	ColorCyan                = colorANSI | 6
+	ColorWhite               = colorANSI | 7
+	ColorBrightBlack         = colorANSI | 8
+	ColorBrightRed           = colorANSI | 9
+	ColorBrightGreen         = colorANSI | 10
+	ColorBrightYellow        = colorANSI | 11
+	ColorBrightBlue          = colorANSI | 12
+	ColorBrightMagenta       = colorANSI | 13
+	ColorBrightCyan          = colorANSI | 14
+	ColorBrightWhite         = colorANSI | 15
+)
+
+// colorNames maps names accepted by ParseColor to standard colors
+var colorNames = []string{
+	"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white",
+	"bright-black", "bright-red", "bright-green", "bright-yellow",
+	"bright-blue", "bright-magenta", "bright-cyan", "bright-white",
+}
+
+// ansiPalette holds the RGB values of the 16 standard colors (xterm defaults)
+var ansiPalette = [16][3]uint8{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// cubeLevels are the channel values of the 6x6x6 cube in the 256-color palette
+var cubeLevels = [6]uint8{0, 95, 135, 175, 215, 255}
+
+// RGB returns a 24-bit color
+func RGB(r, g, b uint8) Color {
+	return colorRGB | Color(r)<<16 | Color(g)<<8 | Color(b)
+}
+
+// Color256 returns an entry of the 256-color palette
+func Color256(n uint8) Color {
+	return color256 | Color(n)
+}
+
+// ParseColor parses a color name ("red", "bright-cyan"), a 256-color palette
+// index ("208") or a hex RGB value ("#ff8800" or "#f80")
+func ParseColor(s string) (Color, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" || s == "none" {
+		return NoColor, nil
+	}
+
+	if hex, ok := strings.CutPrefix(s, "#"); ok {
+		if len(hex) == 3 {
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		}
+		v, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil || len(hex) != 6 {
+			return NoColor, fmt.Errorf("gofig: invalid hex color %q", s)
+		}
+		return RGB(uint8(v>>16), uint8(v>>8), uint8(v)), nil
+	}
+
+	if n, err := strconv.Atoi(s); err == nil {
+		if n < 0 || n > 255 {
+			return NoColor, fmt.Errorf("gofig: palette index %d out of range", n)
+		}
+		return Color256(uint8(n)), nil
+	}
+
+	name := strings.NewReplacer("_", "-", " ", "-").Replace(s)
+	if rest, ok := strings.CutPrefix(name, "bright"); ok && !strings.HasPrefix(rest, "-") {
+		name = "bright-" + rest
+	}
+	if name == "gray" || name == "grey" {
+		name = "bright-black"
+	}
+	for i, n := range colorNames {
+		if n == name {
+			return colorANSI | Color(i), nil
+		}
+	}
+	return NoColor, fmt.Errorf("gofig: unknown color %q", s)
+}
+
+// String returns the color in a form accepted by ParseColor
+func (c Color) String() string {
+	switch c & colorKindMask {
+	case colorANSI:
+		return colorNames[c&0xf]
+	case color256:
+		return strconv.Itoa(int(c & 0xff))
+	case colorRGB:
+		r, g, b := c.RGB()
+		return fmt.Sprintf("#%02x%02x%02x", r, g, b)
+	}
+	return "none"
+}
+
+// RGB returns the red, green and blue components of the color.
+// Palette colors are converted using the common xterm values.
+func (c Color) RGB() (r, g, b uint8) {
+	switch c & colorKindMask {
+	case colorANSI:
+		p := ansiPalette[c&0xf]
+		return p[0], p[1], p[2]
+	case color256:
+		n := int(c & 0xff)
+		switch {
+		case n < 16:
+			p := ansiPalette[n]
+			return p[0], p[1], p[2]
+		case n < 232:
+			n -= 16
+			return cubeLevels[n/36], cubeLevels[n/6%6], cubeLevels[n%6]
+		default:
+			v := uint8(8 + (n-232)*10)
+			return v, v, v
+		}
+	case colorRGB:
+		return uint8(c >> 16), uint8(c >> 8), uint8(c)
+	}
+	return 0, 0, 0
+}
+
+// Foreground returns the escape sequence that sets c as the text color,
+// downgraded to what the profile supports
+func (c Color) Foreground(profile ColorProfile) string {
+	return c.sequence(profile, false)
+}
+
+// Background returns the escape sequence that sets c as the background
+// color, downgraded to what the profile supports
+func (c Color) Background(profile ColorProfile) string {
+	return c.sequence(profile, true)
+}
+
+// sequence builds the SGR escape sequence for the color
+func (c Color) sequence(profile ColorProfile, background bool) string {
+	if profile == ProfileAuto {
+		profile = DetectColorProfile()
+	}
+	if c == NoColor || profile == ProfileNone {
+		return ""
+	}
+	c = c.downgrade(profile)
+
+	switch c & colorKindMask {
+	case colorANSI:
+		n := int(c & 0xf)
+		base := 30
+		if n >= 8 {
+			base, n = 90, n-8
+		}
+		if background {
+			base += 10
+		}
+		return fmt.Sprintf("\033[%dm", base+n)
+	case color256:
+		if background {
+			return fmt.Sprintf("\033[48;5;%dm", c&0xff)
+		}
+		return fmt.Sprintf("\033[38;5;%dm", c&0xff)
+	default:
+		r, g, b := c.RGB()
+		if background {
+			return fmt.Sprintf("\033[48;2;%d;%d;%dm", r, g, b)
+		}
+		return fmt.Sprintf("\033[38;2;%d;%d;%dm", r, g, b)
+	}
+}
+
+// downgrade converts the color to the closest one the profile supports
+func (c Color) downgrade(profile ColorProfile) Color {
+	kind := c & colorKindMask
+	switch {
+	case profile == Profile16 && kind != colorANSI:
+		if kind == color256 && c&0xff < 16 {
+			return colorANSI | c&0xf
+		}
+		return nearestANSI(c.RGB())
+	case profile == Profile256 && kind == colorRGB:
+		return nearest256(c.RGB())
+	}
+	return c
+}
+
+// nearestANSI returns the standard color closest to an RGB value
+func nearestANSI(r, g, b uint8) Color {
+	best, bestDist := 0, -1
+	for i, p := range ansiPalette {
+		if d := colorDistance(r, g, b, p[0], p[1], p[2]); bestDist < 0 || d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return colorANSI | Color(best)
+}
+
+// nearest256 returns the 256-color palette entry closest to an RGB value,
+// choosing between the color cube and the grayscale ramp
+func nearest256(r, g, b uint8) Color {
+	level := func(v uint8) int {
+		best := 0
+		for i, l := range cubeLevels {
+			if absInt(int(v)-int(l)) < absInt(int(v)-int(cubeLevels[best])) {
+				best = i
+			}
+		}
+		return best
+	}
+	ri, gi, bi := level(r), level(g), level(b)
+	cube := 16 + ri*36 + gi*6 + bi
+	cubeDist := colorDistance(r, g, b, cubeLevels[ri], cubeLevels[gi], cubeLevels[bi])
+
+	avg := (int(r) + int(g) + int(b)) / 3
+	gray := min(max((avg-8+5)/10, 0), 23)
+	v := uint8(8 + gray*10)
+	if colorDistance(r, g, b, v, v, v) < cubeDist {
+		return Color256(uint8(232 + gray))
+	}
+	return Color256(uint8(cube))
+}
+
+// colorDistance returns the squared distance between two RGB values
+func colorDistance(r1, g1, b1, r2, g2, b2 uint8) int {
+	dr, dg, db := int(r1)-int(r2), int(g1)-int(g2), int(b1)-int(b2)
+	return dr*dr + dg*dg + db*db
+}
+
+func absInt(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// ColorProfile is the color capability of a terminal
+type ColorProfile int
+
+const (
+	// ProfileAuto detects the profile from the environment
+	ProfileAuto ColorProfile = iota
+	// ProfileNone disables colors
+	ProfileNone
+	// Profile16 supports the 16 standard colors
+	Profile16
+	// Profile256 supports the 256-color palette
+	Profile256
+	// ProfileTrueColor supports 24-bit RGB colors
+	ProfileTrueColor
+)
+
+// DetectColorProfile guesses the color capability of the terminal from
+// the NO_COLOR, COLORTERM and TERM environment variables
+func DetectColorProfile() ColorProfile {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return ProfileNone
+	}
+	switch strings.ToLower(os.Getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return ProfileTrueColor
+	}
+	term := os.Getenv("TERM")
+	switch {
+	case term == "dumb":
+		return ProfileNone
+	case strings.Contains(term, "truecolor") || strings.Contains(term, "24bit") || strings.Contains(term, "direct"):
+		return ProfileTrueColor
+	case strings.Contains(term, "256color"):
+		return Profile256
+	}
+	return Profile16
+}
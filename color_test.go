@@ -0,0 +1,74 @@
+package gofig
+
+import "testing"
+
+func TestParseColor(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Color
+	}{
+		{"", NoColor},
+		{"none", NoColor},
+		{"red", ColorRed},
+		{" Green ", ColorGreen},
+		{"bright-cyan", ColorBrightCyan},
+		{"brightcyan", ColorBrightCyan},
+		{"bright_cyan", ColorBrightCyan},
+		{"Bright White", ColorBrightWhite},
+		{"gray", ColorBrightBlack},
+		{"grey", ColorBrightBlack},
+		{"0", Color256(0)},
+		{"208", Color256(208)},
+		{"255", Color256(255)},
+		{"#ff8800", RGB(0xff, 0x88, 0x00)},
+		{"#FF8800", RGB(0xff, 0x88, 0x00)},
+		{"#f80", RGB(0xff, 0x88, 0x00)},
+		{"#000000", RGB(0, 0, 0)},
+	}
+	for _, tt := range tests {
+		got, err := ParseColor(tt.in)
+		if err != nil {
+			t.Errorf("ParseColor(%q): %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseColor(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseColorErrors(t *testing.T) {
+	tests := []string{
+		"purple",
+		"bright",
+		"bright-",
+		"brighter-red",
+		"256",
+		"-1",
+		"1000",
+		"#",
+		"#12",
+		"#1234",
+		"#12345",
+		"#1234567",
+		"#ggg",
+		"#-12345",
+		"#+12345",
+		"#0x1234",
+		"ff8800",
+	}
+	for _, in := range tests {
+		if c, err := ParseColor(in); err == nil {
+			t.Errorf("ParseColor(%q) = %v, expected an error", in, c)
+		}
+	}
+}
+
+func TestColorStringRoundTrip(t *testing.T) {
+	for _, c := range []Color{NoColor, ColorRed, ColorBrightBlue, Color256(42), RGB(1, 2, 3)} {
+		got, err := ParseColor(c.String())
+		if err != nil || got != c {
+			t.Errorf("ParseColor(%q) = %v, %v; want %v", c.String(), got, err, c)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package gofig
+
+import (
+	"slices"
+	"unicode/utf8"
+)
+
+// Runes returns the runes the font, its fallback fonts and glyphs defined
+// at runtime have glyphs for, in ascending order
+func (bf *BlockFont) Runes() []rune {
+	var runes []rune
+	for r := range bf.glyphs {
+		runes = append(runes, r)
+	}
+	for _, font := range append([]Font{bf.font}, bf.config.FallbackFonts...) {
+		runes = append(runes, font.Runes()...)
+	}
+	slices.Sort(runes)
+	return slices.Compact(runes)
+}
+
+// Supports reports whether r is drawn with a glyph, directly or through
+// case folding, fallback fonts or substitutes
+func (bf *BlockFont) Supports(r rune) bool {
+	_, ok := bf.lookup(r)
+	return ok
+}
+
+// CanRender reports whether every rune of text can be drawn and returns
+// the ones that cannot, each once in order of appearance
+func (bf *BlockFont) CanRender(text string) (bool, []rune) {
+	missing := bf.missingRunes(text)
+	return len(missing) == 0, missing
+}
+
+// Glyph returns the rows of the glyph drawn for r, before scaling, with
+// monospace padding and transforms applied. ok is false if r has no glyph
+// and the rows are the Config.Fallback glyph.
+func (bf *BlockFont) Glyph(r rune) (rows []string, ok bool) {
+	return bf.pattern(r), bf.Supports(r)
+}
+
+// GlyphBitmap returns the glyph drawn for r as a grid of drawn cells
+func (bf *BlockFont) GlyphBitmap(r rune) [][]bool {
+	hardblank := bf.font.Info().Hardblank
+	rows := bf.pattern(r)
+	bitmap := make([][]bool, len(rows))
+	for y, row := range rows {
+		bitmap[y] = make([]bool, 0, utf8.RuneCountInString(row))
+		for _, ch := range row {
+			bitmap[y] = append(bitmap[y], ch != ' ' && ch != hardblank)
+		}
+	}
+	return bitmap
+}
+
+// GlyphWidth returns the width of the glyph drawn for r in glyph columns,
+// before scaling
+func (bf *BlockFont) GlyphWidth(r rune) int {
+	return bf.width(r)
+}
+
+// Height returns the height of a line of text in glyph rows, before scaling
+func (bf *BlockFont) Height() int {
+	return bf.font.Height()
+}
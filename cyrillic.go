@@ -0,0 +1,206 @@
+package gofig
+
+// initCyrillicChars adds the Russian, Ukrainian and Belarusian alphabets
+// to the built-in font. It must run after initChars: letters that look the
+// same as Latin ones reuse their patterns.
+func initCyrillicChars(chars map[rune][]string) {
+	b := "█"
+	s := " "
+
+	// Letters shared with Latin
+	shared := map[rune]rune{
+		'А': 'A', 'В': 'B', 'Е': 'E', 'К': 'K', 'М': 'M', 'Н': 'H',
+		'О': 'O', 'Р': 'P', 'С': 'C', 'Т': 'T', 'Х': 'X', 'І': 'I',
+	}
+	for cyr, lat := range shared {
+		chars[cyr] = chars[lat]
+	}
+
+	// Russian
+	chars['Б'] = []string{
+		b + b + b + b + b,
+		b + s + s + s + s,
+		b + b + b + b + s,
+		b + s + s + s + b,
+		b + b + b + b + s,
+	}
+	chars['Г'] = []string{
+		b + b + b + b + b,
+		b + s + s + s + s,
+		b + s + s + s + s,
+		b + s + s + s + s,
+		b + s + s + s + s,
+	}
+	chars['Д'] = []string{
+		s + b + b + b + s,
+		s + b + s + b + s,
+		s + b + s + b + s,
+		b + b + b + b + b,
+		b + s + s + s + b,
+	}
+	chars['Ё'] = []string{
+		s + b + s + b + s,
+		b + b + b + b + b,
+		b + b + b + b + s,
+		b + s + s + s + s,
+		b + b + b + b + b,
+	}
+	chars['Ж'] = []string{
+		b + s + b + s + b,
+		b + s + b + s + b,
+		s + b + b + b + s,
+		b + s + b + s + b,
+		b + s + b + s + b,
+	}
+	chars['З'] = []string{
+		b + b + b + b + s,
+		s + s + s + s + b,
+		s + b + b + b + s,
+		s + s + s + s + b,
+		b + b + b + b + s,
+	}
+	chars['И'] = []string{
+		b + s + s + s + b,
+		b + s + s + b + b,
+		b + s + b + s + b,
+		b + b + s + s + b,
+		b + s + s + s + b,
+	}
+	chars['Й'] = []string{
+		s + s + b + s + s,
+		b + s + s + s + b,
+		b + s + s + b + b,
+		b + s + b + s + b,
+		b + b + s + s + b,
+	}
+	chars['Л'] = []string{
+		s + s + b + b + b,
+		s + b + s + s + b,
+		s + b + s + s + b,
+		s + b + s + s + b,
+		b + s + s + s + b,
+	}
+	chars['П'] = []string{
+		b + b + b + b + b,
+		b + s + s + s + b,
+		b + s + s + s + b,
+		b + s + s + s + b,
+		b + s + s + s + b,
+	}
+	chars['У'] = []string{
+		b + s + s + s + b,
+		b + s + s + s + b,
+		s + b + b + b + b,
+		s + s + s + s + b,
+		b + b + b + b + s,
+	}
+	chars['Ф'] = []string{
+		s + b + b + b + s,
+		b + s + b + s + b,
+		b + s + b + s + b,
+		s + b + b + b + s,
+		s + s + b + s + s,
+	}
+	chars['Ц'] = []string{
+		b + s + s + b + s,
+		b + s + s + b + s,
+		b + s + s + b + s,
+		b + b + b + b + b,
+		s + s + s + s + b,
+	}
+	chars['Ч'] = []string{
+		b + s + s + s + b,
+		b + s + s + s + b,
+		s + b + b + b + b,
+		s + s + s + s + b,
+		s + s + s + s + b,
+	}
+	chars['Ш'] = []string{
+		b + s + b + s + b,
+		b + s + b + s + b,
+		b + s + b + s + b,
+		b + s + b + s + b,
+		b + b + b + b + b,
+	}
+	chars['Щ'] = []string{
+		b + s + b + s + b,
+		b + s + b + s + b,
+		b + s + b + s + b,
+		b + b + b + b + b,
+		s + s + s + s + b,
+	}
+	chars['Ъ'] = []string{
+		b + b + s + s + s,
+		s + b + s + s + s,
+		s + b + b + b + s,
+		s + b + s + s + b,
+		s + b + b + b + s,
+	}
+	chars['Ы'] = []string{
+		b + s + s + s + b,
+		b + s + s + s + b,
+		b + b + b + s + b,
+		b + s + b + s + b,
+		b + b + b + s + b,
+	}
+	chars['Ь'] = []string{
+		b + s + s + s + s,
+		b + s + s + s + s,
+		b + b + b + b + s,
+		b + s + s + s + b,
+		b + b + b + b + s,
+	}
+	chars['Э'] = []string{
+		b + b + b + b + s,
+		s + s + s + s + b,
+		s + b + b + b + b,
+		s + s + s + s + b,
+		b + b + b + b + s,
+	}
+	chars['Ю'] = []string{
+		b + s + s + b + s,
+		b + s + b + s + b,
+		b + b + b + s + b,
+		b + s + b + s + b,
+		b + s + s + b + s,
+	}
+	chars['Я'] = []string{
+		s + b + b + b + b,
+		b + s + s + s + b,
+		s + b + b + b + b,
+		s + b + s + s + b,
+		b + s + s + s + b,
+	}
+
+	// Ukrainian
+	chars['Є'] = []string{
+		s + b + b + b + b,
+		b + s + s + s + s,
+		b + b + b + b + s,
+		b + s + s + s + s,
+		s + b + b + b + b,
+	}
+	chars['Ї'] = []string{
+		s + b + s + b + s,
+		s + s + s + s + s,
+		s + s + b + s + s,
+		s + s + b + s + s,
+		s + s + b + s + s,
+	}
+	chars['Ґ'] = []string{
+		s + s + s + s + b,
+		b + b + b + b + b,
+		b + s + s + s + s,
+		b + s + s + s + s,
+		b + s + s + s + s,
+	}
+
+	// Belarusian
+	chars['Ў'] = []string{
+		s + s + b + s + s,
+		b + s + s + s + b,
+		s + b + b + b + b,
+		s + s + s + s + b,
+		b + b + b + b + s,
+	}
+}
@@ -4,41 +4,144 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/ant1kvar/gofig"
 )
 
-var colors = map[string]string{
-	"red":     gofig.ColorRed,
-	"green":   gofig.ColorGreen,
-	"yellow":  gofig.ColorYellow,
-	"blue":    gofig.ColorBlue,
-	"magenta": gofig.ColorMagenta,
-	"cyan":    gofig.ColorCyan,
-	"white":   gofig.ColorWhite,
+var layouts = map[string]gofig.Layout{
+	"default": gofig.LayoutDefault,
+	"full":    gofig.LayoutFullWidth,
+	"kern":    gofig.LayoutKerning,
+	"smush":   gofig.LayoutSmushing,
+}
+
+var modes = map[string]gofig.RenderMode{
+	"block":   gofig.ModeBlock,
+	"half":    gofig.ModeHalfBlock,
+	"quad":    gofig.ModeQuadrant,
+	"braille": gofig.ModeBraille,
+}
+
+var gradientDirections = map[string]gofig.GradientDirection{
+	"horizontal": gofig.GradientHorizontal,
+	"vertical":   gofig.GradientVertical,
+	"diagonal":   gofig.GradientDiagonal,
+}
+
+var aligns = map[string]gofig.Align{
+	"left":   gofig.AlignLeft,
+	"center": gofig.AlignCenter,
+	"right":  gofig.AlignRight,
+}
+
+var centers = map[string]gofig.Center{
+	"none":       gofig.CenterNone,
+	"horizontal": gofig.CenterHorizontal,
+	"vertical":   gofig.CenterVertical,
+	"both":       gofig.CenterBoth,
+}
+
+var borders = map[string]gofig.Border{
+	"none":    gofig.BorderNone,
+	"single":  gofig.BorderSingle,
+	"double":  gofig.BorderDouble,
+	"rounded": gofig.BorderRounded,
+	"heavy":   gofig.BorderHeavy,
+	"block":   gofig.BorderBlock,
+}
+
+var fills = map[string]gofig.FillStyle{
+	"solid":   gofig.FillSolid,
+	"outline": gofig.FillOutline,
+	"box":     gofig.FillOutlineBox,
+	"hollow":  gofig.FillHollow,
+}
+
+var transforms = map[string]gofig.Transform{
+	"italic":    gofig.TransformItalic,
+	"bold":      gofig.TransformBold,
+	"condensed": gofig.TransformCondensed,
+	"expanded":  gofig.TransformExpanded,
+	"mirror":    gofig.TransformMirror,
+	"flip":      gofig.TransformFlip,
+}
+
+var orientations = map[string]gofig.Orientation{
+	"horizontal": gofig.OrientationHorizontal,
+	"vertical":   gofig.OrientationVertical,
+	"90":         gofig.OrientationRotate90,
+	"180":        gofig.OrientationRotate180,
+	"270":        gofig.OrientationRotate270,
+}
+
+var fallbacks = map[string]gofig.Fallback{
+	"blank": gofig.FallbackBlank,
+	"tofu":  gofig.FallbackTofu,
 }
 
 var animTypes = map[string]gofig.AnimationType{
-	"blink":    gofig.AnimBlink,
-	"pulse":    gofig.AnimPulse,
-	"wave":     gofig.AnimWave,
-	"typing":   gofig.AnimTyping,
-	"glitch":   gofig.AnimGlitch,
-	"sequence": gofig.AnimSequence,
-	"random":   gofig.AnimRandom,
+	"blink":     gofig.AnimBlink,
+	"pulse":     gofig.AnimPulse,
+	"wave":      gofig.AnimWave,
+	"typing":    gofig.AnimTyping,
+	"glitch":    gofig.AnimGlitch,
+	"sequence":  gofig.AnimSequence,
+	"highlight": gofig.AnimHighlight,
+	"random":    gofig.AnimRandom,
 }
 
 func main() {
 	// Основные настройки
 	scale := flag.Int("scale", 1, "Scale factor (1-5)")
+	scaleX := flag.Int("scale-x", 0, "Horizontal scale factor (0 = -scale)")
+	scaleY := flag.Int("scale-y", 0, "Vertical scale factor (0 = -scale)")
+	aspect := flag.Bool("aspect", false, "Correct the aspect ratio so glyph cells look square")
 	char := flag.String("char", "█", "Block character to use")
 	space := flag.String("space", " ", "Space character (e.g., '.', '_')")
-	color := flag.String("color", "", "Color: red, green, yellow, blue, magenta, cyan, white")
+	color := flag.String("color", "", "Color: name (red, bright-cyan, ...), palette index (0-255) or hex (#ff8800)")
+	bg := flag.String("bg", "", "Background color of text cells")
+	spaceColor := flag.String("space-color", "", "Color of the space character")
+	spaceBg := flag.String("space-bg", "", "Background color of empty cells (badge panel)")
+	reverse := flag.Bool("reverse", false, "Reverse video: cut the text out of a solid block")
+	gradient := flag.String("gradient", "", "Gradient color stops, comma separated (e.g., red,#0088ff)")
+	gradientDir := flag.String("gradient-dir", "horizontal", "Gradient direction: horizontal, vertical, diagonal")
+	fontPath := flag.String("font", "", "Path to a font file: FIGlet .flf or gofig font")
+	saveFont := flag.String("save-font", "", "Write the font to a file (.flf as FIGlet, otherwise gofig font) and exit")
+	preserveCase := flag.Bool("preserve-case", false, "Draw lowercase letters with lowercase glyphs")
+	fallback := flag.String("fallback", "blank", "Glyph for unsupported characters: blank, tofu")
+	decompose := flag.Bool("decompose", false, "Draw accented letters without glyphs as base letters (É as E)")
+	list := flag.Bool("list", false, "Print the characters the font can draw and exit")
+	preview := flag.Bool("preview", false, "Render every character the font can draw")
+	strict := flag.Bool("strict", false, "Fail if the text has characters the font cannot draw")
+	mono := flag.Bool("mono", false, "Monospace glyphs (pad every letter to the same width)")
+	layout := flag.String("layout", "default", "Layout: default, full, kern, smush")
+	mode := flag.String("mode", "block", "Render mode: block, half, quad, braille")
+	align := flag.String("align", "left", "Alignment of multi-line text: left, center, right")
+	lineSpacing := flag.Int("line-spacing", 1, "Empty rows between lines of text")
+	width := flag.Int("width", -1, "Wrap text to this many columns (0 = no wrapping, -1 = terminal width)")
+	padding := flag.Int("padding", 0, "Empty cells between the text and the edge of its panel")
+	margin := flag.Int("margin", 0, "Empty cells around the panel")
+	orientation := flag.String("orientation", "horizontal", "Text direction: horizontal, vertical, 90, 180, 270")
+	transform := flag.String("transform", "", "Glyph transforms, comma separated: italic, bold, condensed, expanded, mirror, flip")
+	fill := flag.String("fill", "solid", "Glyph fill: solid, outline, box, hollow")
+	hollowChar := flag.String("hollow-char", "░", "Character inside hollow glyphs")
+	shadow := flag.String("shadow", "", "Shadow offset as x,y in glyph cells (e.g., 1,1)")
+	depth := flag.Int("depth", 1, "Shadow layers (more than 1 gives a 3D extrusion)")
+	shadowChar := flag.String("shadow-char", "░", "Shadow character")
+	shadowColor := flag.String("shadow-color", "", "Shadow color")
+	shadowEnd := flag.String("shadow-end", "", "Color of the farthest shadow layer")
+	border := flag.String("border", "none", "Border: none, single, double, rounded, heavy, block")
+	borderColor := flag.String("border-color", "", "Border color")
+	title := flag.String("title", "", "Title shown in the top edge of the border")
+	center := flag.String("center", "none", "Center in the terminal: none, horizontal, vertical, both")
+	hyphenate := flag.Bool("hyphenate", true, "Add '-' where overlong words are broken")
 
 	// Настройки анимации
-	anim := flag.String("anim", "", "Animation: blink, pulse, wave, typing, glitch, sequence, random")
+	anim := flag.String("anim", "", "Animation: blink, pulse, wave, typing, glitch, sequence, highlight, random")
 	interval := flag.Int("interval", 100, "Animation interval in ms")
 	chance := flag.Float64("chance", 0.3, "Effect chance (0.0-1.0)")
 	min := flag.Int("min", 1, "Minimum affected letters")
@@ -47,14 +150,22 @@ func main() {
 	switchFrames := flag.Int("switch", 30, "Frames before switching animation (for random mode)")
 	duration := flag.Int("duration", 0, "Animation duration in seconds (0 = infinite)")
 
+	// Глифы, заданные в командной строке: символ=строки через /
+	var glyphs []string
+	flag.Func("glyph", "Define a glyph as char=rows, rows separated by / (# = filled, . = empty); repeatable", func(v string) error {
+		glyphs = append(glyphs, v)
+		return nil
+	})
+
 	flag.Parse()
 
 	args := flag.Args()
-	if len(args) == 0 {
+	if len(args) == 0 && !*list && !*preview && *saveFont == "" {
 		fmt.Println("Usage: textblock [options] <text>")
 		fmt.Println("\nOptions:")
 		flag.PrintDefaults()
-		fmt.Println("\nColors: red, green, yellow, blue, magenta, cyan, white")
+		fmt.Println("\nColors: black, red, green, yellow, blue, magenta, cyan, white,")
+		fmt.Println("        bright-<name>, palette index 0-255 or hex #rrggbb")
 		fmt.Println("\nAnimations:")
 		fmt.Println("  blink    - Random letter blinking")
 		fmt.Println("  pulse    - Whole text pulses")
@@ -62,11 +173,36 @@ func main() {
 		fmt.Println("  typing   - Typewriter effect")
 		fmt.Println("  glitch   - Glitch/corruption effect")
 		fmt.Println("  sequence - Sequential letter blinking")
+		fmt.Println("  highlight - Random letters shown bold and bright")
 		fmt.Println("  random   - Randomly switches between animations")
 		fmt.Println("\nExamples:")
 		fmt.Println("  textblock Hello")
 		fmt.Println("  textblock -scale=2 -color=green OK")
+		fmt.Println("  textblock -aspect WIDE")
+		fmt.Println("  textblock -preserve-case Hello")
+		fmt.Println("  textblock -glyph='~=...../.#..#/#.##./...../.....' 'A~B'")
+		fmt.Println("  textblock -font=standard.flf -preview")
+		fmt.Println("  textblock -decompose -fallback=tofu CAFÉ €5")
+		fmt.Println("  textblock -color='#ff8800' ORANGE")
+		fmt.Println("  textblock -gradient='#ff0080,#00c0ff' LOGO")
+		fmt.Println("  textblock -color=bright-white -space-bg=red -bg=red BADGE")
+		fmt.Println("  textblock -reverse -color=blue INVERT")
+		fmt.Println("  textblock -align=center 'HELLO\\nWORLD'")
+		fmt.Println("  textblock -width=40 THE QUICK BROWN FOX")
+		fmt.Println("  textblock -transform=italic,bold FAST")
+		fmt.Println("  textblock -orientation=vertical -align=center SIDE")
+		fmt.Println("  textblock -fill=box -scale=2 OUTLINE")
+		fmt.Println("  textblock -shadow=1,1 -shadow-color=bright-black LOGO")
+		fmt.Println("  textblock -shadow=1,1 -depth=3 -shadow-char=█ -shadow-color=red -shadow-end=black LOGO")
+		fmt.Println("  textblock -border=rounded -title=status -padding=1 OK")
+		fmt.Println("  textblock -center=both -padding=1 -space-bg=blue -anim=wave SPLASH")
 		fmt.Println("  textblock -char='#' -space='.' DOTS")
+		fmt.Println("  textblock -font=standard.flf Hello")
+		fmt.Println("  textblock -font=standard.flf -save-font=standard.gff")
+		fmt.Println("  textblock -font=standard.gff Hello")
+		fmt.Println("  textblock -layout=kern LOGO")
+		fmt.Println("  textblock -mode=half -scale=2 COMPACT")
+		fmt.Println("  textblock -mode=braille STATUS")
 		fmt.Println("  textblock -anim=blink ERROR")
 		fmt.Println("  textblock -anim=wave -color=cyan LOADING")
 		fmt.Println("  textblock -anim=typing -interval=150 HELLO")
@@ -75,20 +211,204 @@ func main() {
 		os.Exit(1)
 	}
 
-	text := strings.Join(args, " ")
+	// "\n" в тексте разделяет строки
+	text := strings.ReplaceAll(strings.Join(args, " "), `\n`, "\n")
 
 	// Настройки шрифта
 	fontConfig := gofig.DefaultConfig()
 	fontConfig.Scale = *scale
+	fontConfig.ScaleX = *scaleX
+	fontConfig.ScaleY = *scaleY
 	fontConfig.Char = *char
 	fontConfig.Space = *space
-	if c, ok := colors[*color]; ok {
-		fontConfig.Color = c
+	fontConfig.Monospace = *mono
+	fontConfig.PreserveCase = *preserveCase
+	fontConfig.Decompose = *decompose
+	fontConfig.Reverse = *reverse
+	fontConfig.LineSpacing = *lineSpacing
+	fontConfig.MaxWidth = *width
+	fontConfig.Hyphenate = *hyphenate
+	fontConfig.Padding = gofig.Uniform(*padding)
+	fontConfig.Margin = gofig.Uniform(*margin)
+	fontConfig.Title = *title
+	fontConfig.HollowChar = *hollowChar
+	if *gradient != "" {
+		dir, ok := gradientDirections[*gradientDir]
+		if !ok {
+			fmt.Printf("Unknown gradient direction: %s\n", *gradientDir)
+			fmt.Println("Available: horizontal, vertical, diagonal")
+			os.Exit(1)
+		}
+		var stops []gofig.Color
+		for _, name := range strings.Split(*gradient, ",") {
+			c, err := gofig.ParseColor(name)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+			stops = append(stops, c)
+		}
+		fontConfig.Gradient = gofig.NewGradient(dir, stops...)
+	}
+	if l, ok := layouts[*layout]; ok {
+		fontConfig.Layout = l
+	} else {
+		fmt.Printf("Unknown layout: %s\n", *layout)
+		fmt.Println("Available: default, full, kern, smush")
+		os.Exit(1)
+	}
+	if m, ok := modes[*mode]; ok {
+		fontConfig.Mode = m
+	} else {
+		fmt.Printf("Unknown mode: %s\n", *mode)
+		fmt.Println("Available: block, half, quad, braille")
+		os.Exit(1)
+	}
+	if a, ok := aligns[*align]; ok {
+		fontConfig.Align = a
+	} else {
+		fmt.Printf("Unknown alignment: %s\n", *align)
+		fmt.Println("Available: left, center, right")
+		os.Exit(1)
+	}
+	if f, ok := fallbacks[*fallback]; ok {
+		fontConfig.Fallback = f
+	} else {
+		fmt.Printf("Unknown fallback: %s\n", *fallback)
+		fmt.Println("Available: blank, tofu")
+		os.Exit(1)
+	}
+	if o, ok := orientations[*orientation]; ok {
+		fontConfig.Orientation = o
+	} else {
+		fmt.Printf("Unknown orientation: %s\n", *orientation)
+		fmt.Println("Available: horizontal, vertical, 90, 180, 270")
+		os.Exit(1)
+	}
+	if *transform != "" {
+		for _, name := range strings.Split(*transform, ",") {
+			t, ok := transforms[strings.TrimSpace(name)]
+			if !ok {
+				fmt.Printf("Unknown transform: %s\n", name)
+				fmt.Println("Available: italic, bold, condensed, expanded, mirror, flip")
+				os.Exit(1)
+			}
+			fontConfig.Transform |= t
+		}
+	}
+	if f, ok := fills[*fill]; ok {
+		fontConfig.Fill = f
+	} else {
+		fmt.Printf("Unknown fill: %s\n", *fill)
+		fmt.Println("Available: solid, outline, box, hollow")
+		os.Exit(1)
+	}
+	if *shadow != "" {
+		var x, y int
+		if _, err := fmt.Sscanf(*shadow, "%d,%d", &x, &y); err != nil {
+			fmt.Printf("Invalid shadow offset: %s\n", *shadow)
+			os.Exit(1)
+		}
+		fontConfig.Shadow = gofig.Shadow{X: x, Y: y, Depth: *depth, Char: *shadowChar}
+	}
+	if b, ok := borders[*border]; ok {
+		fontConfig.Border = b
+	} else {
+		fmt.Printf("Unknown border: %s\n", *border)
+		fmt.Println("Available: none, single, double, rounded, heavy, block")
+		os.Exit(1)
+	}
+	if c, ok := centers[*center]; ok {
+		fontConfig.Center = c
+	} else {
+		fmt.Printf("Unknown centering: %s\n", *center)
+		fmt.Println("Available: none, horizontal, vertical, both")
+		os.Exit(1)
+	}
+	colors := []struct {
+		value  string
+		target *gofig.Color
+	}{
+		{*color, &fontConfig.Color},
+		{*bg, &fontConfig.Background},
+		{*spaceColor, &fontConfig.SpaceColor},
+		{*spaceBg, &fontConfig.SpaceBackground},
+		{*borderColor, &fontConfig.BorderColor},
+		{*shadowColor, &fontConfig.Shadow.Color},
+		{*shadowEnd, &fontConfig.Shadow.EndColor},
+	}
+	for _, opt := range colors {
+		c, err := gofig.ParseColor(opt.value)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		*opt.target = c
+	}
+
+	bf := gofig.NewWithConfig(fontConfig)
+	if *fontPath != "" {
+		// Формат шрифта определяется по расширению файла
+		var font gofig.Font
+		var err error
+		if strings.EqualFold(filepath.Ext(*fontPath), ".flf") {
+			font, err = gofig.LoadFIGletFont(*fontPath)
+		} else {
+			font, err = gofig.LoadFont(*fontPath)
+		}
+		if err != nil {
+			fmt.Printf("Failed to load font: %v\n", err)
+			os.Exit(1)
+		}
+		bf = gofig.NewWithFont(font, fontConfig)
+	}
+	if *aspect {
+		bf.SetAspectCorrect(*scale)
+	}
+	for _, g := range glyphs {
+		char, rows, ok := strings.Cut(g, "=")
+		if !ok || utf8.RuneCountInString(char) != 1 {
+			fmt.Printf("Invalid glyph definition: %s\n", g)
+			os.Exit(1)
+		}
+		r, _ := utf8.DecodeRuneInString(char)
+		if err := bf.DefineGlyph(r, strings.ReplaceAll(rows, "/", "\n")); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
+	if *saveFont != "" {
+		save := gofig.SaveFont
+		if strings.EqualFold(filepath.Ext(*saveFont), ".flf") {
+			save = gofig.SaveFIGletFont
+		}
+		if err := save(*saveFont, bf.Font()); err != nil {
+			fmt.Printf("Failed to save font: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	if *list {
+		fmt.Println(string(bf.Runes()))
+		return
+	}
+	if *preview {
+		// Пробелы между символами, чтобы перенос шёл по ним
+		chars := make([]string, 0, len(bf.Runes()))
+		for _, r := range bf.Runes() {
+			chars = append(chars, string(r))
+		}
+		text = strings.Join(chars, " ")
+	}
+	if *strict {
+		if _, err := bf.RenderE(text); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
 	}
 
 	// Если анимация не задана - просто вывести текст
 	if *anim == "" {
-		bf := gofig.NewWithConfig(fontConfig)
 		fmt.Println(bf.Render(text))
 		return
 	}
@@ -99,7 +419,7 @@ func main() {
 		animConfig.Type = animType
 	} else {
 		fmt.Printf("Unknown animation: %s\n", *anim)
-		fmt.Println("Available: blink, pulse, wave, typing, glitch, sequence, random")
+		fmt.Println("Available: blink, pulse, wave, typing, glitch, sequence, highlight, random")
 		os.Exit(1)
 	}
 
@@ -111,7 +431,7 @@ func main() {
 	animConfig.RandomSwitchFrames = *switchFrames
 
 	// Запуск анимации
-	animation := gofig.NewAnimationWithConfig(text, fontConfig, animConfig)
+	animation := gofig.NewAnimationWithFont(text, bf, animConfig)
 
 	if *duration > 0 {
 		go func() {
@@ -0,0 +1,211 @@
+package gofig
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Fallback selects what is drawn for runes no font has a glyph for
+type Fallback int
+
+const (
+	// FallbackBlank draws a blank glyph the width of a space
+	FallbackBlank Fallback = iota
+	// FallbackTofu draws a hollow box so missing glyphs are easy to spot
+	FallbackTofu
+)
+
+// decompositions is a transliteration table from accented Latin letters
+// to their base letters. It holds the canonical decompositions of the
+// Latin-1 Supplement, Latin Extended-A and -B and Latin Extended Additional
+// blocks (UnicodeData 14.0) with the combining marks dropped, so letters with
+// several marks such as Ệ map to E, plus stroked letters without a
+// decomposition (Ø, Ł, Đ, Ħ, Ŧ, ı).
+var decompositions = map[rune]rune{}
+
+func init() {
+	groups := map[rune]string{
+		'A': "ÀÁÂÃÄÅĀĂĄǍǞǠǺȀȂȦḀẠẢẤẦẨẪẬẮẰẲẴẶ", 'a': "àáâãäåāăąǎǟǡǻȁȃȧḁạảấầẩẫậắằẳẵặ",
+		'B': "ḂḄḆ", 'b': "ḃḅḇ",
+		'C': "ÇĆĈĊČḈ", 'c': "çćĉċčḉ",
+		'D': "ĎḊḌḎḐḒ", 'd': "ďḋḍḏḑḓ",
+		'E': "ÈÉÊËĒĔĖĘĚȄȆȨḔḖḘḚḜẸẺẼẾỀỂỄỆ", 'e': "èéêëēĕėęěȅȇȩḕḗḙḛḝẹẻẽếềểễệ",
+		'F': "Ḟ", 'f': "ḟ",
+		'G': "ĜĞĠĢǦǴḠ", 'g': "ĝğġģǧǵḡ",
+		'H': "ĤȞḢḤḦḨḪ", 'h': "ĥȟḣḥḧḩḫẖ",
+		'I': "ÌÍÎÏĨĪĬĮİǏȈȊḬḮỈỊ", 'i': "ìíîïĩīĭįǐȉȋḭḯỉị",
+		'J': "Ĵ", 'j': "ĵǰ",
+		'K': "ĶǨḰḲḴ", 'k': "ķǩḱḳḵ",
+		'L': "ĹĻĽḶḸḺḼ", 'l': "ĺļľḷḹḻḽ",
+		'M': "ḾṀṂ", 'm': "ḿṁṃ",
+		'N': "ÑŃŅŇǸṄṆṈṊ", 'n': "ñńņňǹṅṇṉṋ",
+		'O': "ÒÓÔÕÖŌŎŐƠǑǪǬȌȎȪȬȮȰṌṎṐṒỌỎỐỒỔỖỘỚỜỞỠỢ", 'o': "òóôõöōŏőơǒǫǭȍȏȫȭȯȱṍṏṑṓọỏốồổỗộớờởỡợ",
+		'P': "ṔṖ", 'p': "ṕṗ",
+		'R': "ŔŖŘȐȒṘṚṜṞ", 'r': "ŕŗřȑȓṙṛṝṟ",
+		'S': "ŚŜŞŠȘṠṢṤṦṨ", 's': "śŝşšșṡṣṥṧṩ",
+		'T': "ŢŤȚṪṬṮṰ", 't': "ţťțṫṭṯṱẗ",
+		'U': "ÙÚÛÜŨŪŬŮŰŲƯǓǕǗǙǛȔȖṲṴṶṸṺỤỦỨỪỬỮỰ", 'u': "ùúûüũūŭůűųưǔǖǘǚǜȕȗṳṵṷṹṻụủứừửữự",
+		'V': "ṼṾ", 'v': "ṽṿ",
+		'W': "ŴẀẂẄẆẈ", 'w': "ŵẁẃẅẇẉẘ",
+		'X': "ẊẌ", 'x': "ẋẍ",
+		'Y': "ÝŶŸȲẎỲỴỶỸ", 'y': "ýÿŷȳẏẙỳỵỷỹ",
+		'Z': "ŹŻŽẐẒẔ", 'z': "źżžẑẓẕ",
+	}
+	// Stroked, dotless and middle-dot letters have no canonical decomposition
+	strokes := map[rune]string{
+		'D': "Đ", 'd': "đ",
+		'H': "Ħ", 'h': "ħ",
+		'i': "ı",
+		'L': "ĿŁ", 'l': "ŀł",
+		'O': "Ø", 'o': "ø",
+		'T': "Ŧ", 't': "ŧ",
+	}
+	for _, table := range []map[rune]string{groups, strokes} {
+		for base, letters := range table {
+			for _, r := range letters {
+				decompositions[r] = base
+			}
+		}
+	}
+}
+
+// MissingGlyphsError reports runes that no font could draw
+type MissingGlyphsError struct {
+	// Runes lists every missing rune once, in order of appearance
+	Runes []rune
+}
+
+func (e *MissingGlyphsError) Error() string {
+	quoted := make([]string, len(e.Runes))
+	for i, r := range e.Runes {
+		quoted[i] = fmt.Sprintf("%q", r)
+	}
+	return "gofig: no glyphs for " + strings.Join(quoted, ", ")
+}
+
+// lookup finds the glyph for ch among glyphs defined at runtime, in the
+// font and in Config.FallbackFonts, then does the same for the substitute
+// of ch from Config.Substitutes or decomposition. Glyphs defined at runtime
+// and substitutes are looked up by the rune as written first, then by its
+// uppercase form.
+func (bf *BlockFont) lookup(ch rune) ([]string, bool) {
+	if rows, ok := bf.glyphs[ch]; ok {
+		return rows, true
+	}
+	folded := ch
+	if !bf.config.PreserveCase {
+		folded = unicode.ToUpper(ch)
+	}
+	if rows, ok := bf.lookupFonts(folded); ok {
+		return rows, true
+	}
+
+	sub, ok := bf.config.Substitutes[ch]
+	if !ok {
+		sub, ok = bf.config.Substitutes[folded]
+	}
+	if !ok && bf.config.Decompose {
+		sub, ok = decompositions[folded]
+	}
+	if !ok {
+		return nil, false
+	}
+	if !bf.config.PreserveCase {
+		sub = unicode.ToUpper(sub)
+	}
+	return bf.lookupFonts(sub)
+}
+
+// lookupFonts finds the glyph for ch among glyphs defined at runtime, in
+// the font and in its fallback chain. Fonts without lowercase letters still
+// draw them in uppercase.
+func (bf *BlockFont) lookupFonts(ch rune) ([]string, bool) {
+	fonts := append([]Font{bf.font}, bf.config.FallbackFonts...)
+	for _, r := range []rune{ch, unicode.ToUpper(ch)} {
+		if rows, ok := bf.glyphs[r]; ok {
+			return rows, true
+		}
+		for i, font := range fonts {
+			if rows, ok := font.Glyph(r); ok {
+				if i > 0 {
+					rows = fitHeight(rows, bf.font.Height())
+				}
+				return rows, true
+			}
+		}
+		if r == unicode.ToUpper(r) {
+			break
+		}
+	}
+	return nil, false
+}
+
+// missing returns the glyph drawn for a rune no font has
+func (bf *BlockFont) missing() []string {
+	height := bf.font.Height()
+	if bf.config.Fallback == FallbackTofu {
+		return tofu(height)
+	}
+	if rows, ok := bf.font.Glyph(' '); ok {
+		return rows
+	}
+	return make([]string, height)
+}
+
+// tofu returns a hollow box glyph of the given height
+func tofu(height int) []string {
+	rows := make([]string, height)
+	for i := range rows {
+		switch i {
+		case 0, height - 1:
+			rows[i] = "████"
+		default:
+			rows[i] = "█  █"
+		}
+	}
+	return rows
+}
+
+// fitHeight pads or cuts glyph rows at the bottom to the given height
+func fitHeight(rows []string, height int) []string {
+	if len(rows) >= height {
+		return rows[:height]
+	}
+	fitted := append([]string(nil), rows...)
+	blank := ""
+	if len(rows) > 0 {
+		blank = strings.Repeat(" ", len([]rune(rows[0])))
+	}
+	for len(fitted) < height {
+		fitted = append(fitted, blank)
+	}
+	return fitted
+}
+
+// missingRunes returns the runes of text no font has a glyph for
+func (bf *BlockFont) missingRunes(text string) []rune {
+	var missing []rune
+	seen := make(map[rune]bool)
+	for _, r := range text {
+		if r == '\n' || r == '\r' || seen[r] {
+			continue
+		}
+		seen[r] = true
+		if _, ok := bf.lookup(r); !ok {
+			missing = append(missing, r)
+		}
+	}
+	return missing
+}
+
+// RenderE renders text like Render and also reports runes that no font
+// has a glyph for as a *MissingGlyphsError. The output is returned either
+// way, with missing glyphs drawn according to Config.Fallback.
+func (bf *BlockFont) RenderE(text string) (string, error) {
+	out := bf.Render(text)
+	if missing := bf.missingRunes(text); len(missing) > 0 {
+		return out, &MissingGlyphsError{Runes: missing}
+	}
+	return out, nil
+}
@@ -0,0 +1,38 @@
+package gofig
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDecompose(t *testing.T) {
+	config := DefaultConfig()
+	config.Decompose = true
+	bf := NewWithConfig(config)
+
+	tests := []struct {
+		r, want rune
+	}{
+		{'É', 'E'},
+		{'é', 'E'},
+		{'Ș', 'S'},
+		{'ț', 'T'},
+		{'Ệ', 'E'},
+		{'ữ', 'U'},
+		{'Ǖ', 'U'},
+		{'Ø', 'O'},
+		{'ł', 'L'},
+		{'ı', 'I'},
+	}
+	for _, tt := range tests {
+		got, ok := bf.lookup(tt.r)
+		want, _ := bf.lookup(tt.want)
+		if !ok || !slices.Equal(got, want) {
+			t.Errorf("lookup(%q) = %q, want the glyph of %q", tt.r, got, tt.want)
+		}
+	}
+
+	if _, ok := bf.lookup('ß'); ok {
+		t.Error("lookup('ß') found a glyph; ß has no decomposition")
+	}
+}
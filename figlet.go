@@ -0,0 +1,337 @@
+package gofig
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// figletDeutsch lists the code points of the seven required glyphs that
+// follow the printable ASCII range in every FIGlet font
+var figletDeutsch = []rune{196, 214, 220, 228, 246, 252, 223}
+
+// FIGletFont is a font loaded from a FIGlet .flf file.
+// It implements Font; the hardblank, baseline and comment are reported by
+// Info, the remaining header values are kept as fields.
+type FIGletFont struct {
+	*BitmapFont
+
+	// MaxLength is the longest line in the file, including endmarks
+	MaxLength int
+	// OldLayout is the layout value from the header
+	OldLayout int
+	// FullLayout is the extended layout value (-1 if not present)
+	FullLayout int
+	// PrintDirection is 0 for left-to-right and 1 for right-to-left
+	PrintDirection int
+}
+
+// LoadFIGletFont reads a FIGlet font from a .flf file
+func LoadFIGletFont(path string) (*FIGletFont, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	font, err := ParseFIGletFont(file)
+	if err != nil {
+		return nil, err
+	}
+	font.info.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return font, nil
+}
+
+// ParseFIGletFont reads a FIGlet font from r
+func ParseFIGletFont(r io.Reader) (*FIGletFont, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNum := 0
+	next := func() (string, bool) {
+		if !scanner.Scan() {
+			return "", false
+		}
+		lineNum++
+		return scanner.Text(), true
+	}
+
+	header, ok := next()
+	if !ok {
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("figlet: empty font file")
+	}
+	font, commentLines, err := parseFIGletHeader(header)
+	if err != nil {
+		return nil, err
+	}
+
+	comments := make([]string, 0, commentLines)
+	for i := 0; i < commentLines; i++ {
+		line, ok := next()
+		if !ok {
+			return nil, fmt.Errorf("figlet: unexpected end of file in comments")
+		}
+		comments = append(comments, line)
+	}
+	font.info.Comment = strings.Join(comments, "\n")
+
+	readGlyph := func() ([]string, error) {
+		rows := make([]string, font.height)
+		for i := range rows {
+			line, ok := next()
+			if !ok {
+				return nil, io.ErrUnexpectedEOF
+			}
+			rows[i] = trimFIGletLine(line)
+		}
+		return padRows(rows), nil
+	}
+
+	// Required glyphs: printable ASCII followed by the Deutsch set
+	required := make([]rune, 0, 95+len(figletDeutsch))
+	for ch := rune(32); ch <= 126; ch++ {
+		required = append(required, ch)
+	}
+	required = append(required, figletDeutsch...)
+
+	for _, ch := range required {
+		rows, err := readGlyph()
+		if err == io.ErrUnexpectedEOF && ch > 126 {
+			// Some fonts omit the Deutsch set; keep what was read
+			return font, scanner.Err()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("figlet: line %d: glyph %q: %w", lineNum, ch, err)
+		}
+		font.chars[ch] = rows
+	}
+
+	// Code-tagged glyphs until end of file
+	for {
+		line, ok := next()
+		if !ok {
+			break
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		code, err := parseFIGletCode(line)
+		if err != nil {
+			return nil, fmt.Errorf("figlet: line %d: %w", lineNum, err)
+		}
+		rows, err := readGlyph()
+		if err != nil {
+			return nil, fmt.Errorf("figlet: line %d: glyph %d: %w", lineNum, code, err)
+		}
+		// Negative codes belong to translation tables and are not drawable
+		if code >= 0 && code <= utf8.MaxRune {
+			font.chars[rune(code)] = rows
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return font, nil
+}
+
+// parseFIGletHeader parses the first line of a font file and returns
+// the font skeleton and the number of comment lines that follow
+func parseFIGletHeader(header string) (*FIGletFont, int, error) {
+	fields := strings.Fields(header)
+	if len(fields) < 6 {
+		return nil, 0, fmt.Errorf("figlet: malformed header %q", header)
+	}
+
+	signature := []rune(fields[0])
+	if len(signature) != 6 || (string(signature[:4]) != "flf2" && string(signature[:4]) != "tlf2") {
+		return nil, 0, fmt.Errorf("figlet: not a FIGlet font (signature %q)", fields[0])
+	}
+
+	nums := make([]int, 0, len(fields)-1)
+	for _, field := range fields[1:] {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			break
+		}
+		nums = append(nums, n)
+	}
+	if len(nums) < 5 {
+		return nil, 0, fmt.Errorf("figlet: malformed header %q", header)
+	}
+	if nums[0] < 1 {
+		return nil, 0, fmt.Errorf("figlet: invalid height %d", nums[0])
+	}
+
+	font := &FIGletFont{
+		// FIGlet glyphs carry their own spacing
+		BitmapFont: NewBitmapFont(FontInfo{Hardblank: signature[5], Baseline: nums[1]}, nums[0]),
+		MaxLength:  nums[2],
+		OldLayout:  nums[3],
+		FullLayout: -1,
+	}
+	if len(nums) > 5 {
+		font.PrintDirection = nums[5]
+	}
+	if len(nums) > 6 {
+		font.FullLayout = nums[6]
+	}
+	font.info.Layout, font.info.SmushRules = figletLayout(font.OldLayout, font.FullLayout)
+	return font, nums[4], nil
+}
+
+// parseFIGletCode parses the code at the start of a code tag line.
+// Decimal, octal (leading 0) and hex (leading 0x) values are accepted.
+func parseFIGletCode(line string) (int64, error) {
+	fields := strings.Fields(line)
+	code, err := strconv.ParseInt(fields[0], 0, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid code tag %q", fields[0])
+	}
+	return code, nil
+}
+
+// trimFIGletLine strips trailing whitespace and endmarks from a glyph row
+func trimFIGletLine(line string) string {
+	line = strings.TrimRight(line, " \t\r\n")
+	if line == "" {
+		return line
+	}
+	endmark, _ := utf8.DecodeLastRuneInString(line)
+	return strings.TrimRight(line, string(endmark))
+}
+
+// SaveFIGletFont writes a font to a file in the FIGlet format
+func SaveFIGletFont(path string, font Font) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := WriteFIGletFont(file, font); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// WriteFIGletFont writes any font in the FIGlet .flf format. The font's
+// spacing is added to every glyph, missing required glyphs are written
+// empty and characters outside of them become code-tagged glyphs. Fonts
+// with non-ASCII glyph characters get the TOIlet "tlf2a" signature.
+func WriteFIGletFont(w io.Writer, font Font) error {
+	info := font.Info()
+	height := font.Height()
+
+	required := make(map[rune]bool, 95+len(figletDeutsch))
+	order := make([]rune, 0, 95+len(figletDeutsch))
+	for ch := rune(32); ch <= 126; ch++ {
+		order = append(order, ch)
+	}
+	order = append(order, figletDeutsch...)
+	for _, ch := range order {
+		required[ch] = true
+	}
+	var tagged []rune
+	for _, r := range font.Runes() {
+		if !required[r] {
+			tagged = append(tagged, r)
+		}
+	}
+
+	// The hardblank and endmark must not clash with glyph characters
+	used := map[rune]bool{}
+	ascii := true
+	maxWidth := 0
+	for _, r := range append(order, tagged...) {
+		rows, _ := font.Glyph(r)
+		for _, row := range rows {
+			maxWidth = max(maxWidth, utf8.RuneCountInString(row))
+			for _, ch := range row {
+				used[ch] = true
+				ascii = ascii && ch < utf8.RuneSelf
+			}
+		}
+	}
+	hardblank := info.Hardblank
+	if hardblank == 0 {
+		r, err := unusedRune("$~^`", used)
+		if err != nil {
+			return err
+		}
+		hardblank = r
+	}
+	delete(used, hardblank)
+	endmark, err := unusedRune("@#|!", used)
+	if err != nil {
+		return err
+	}
+
+	signature := "flf2a"
+	if !ascii {
+		signature = "tlf2a"
+	}
+	baseline := info.Baseline
+	if baseline < 1 || baseline > height {
+		baseline = height
+	}
+	oldLayout, fullLayout := figletLayoutValues(info.Layout, info.SmushRules)
+	var comments []string
+	if info.Comment != "" {
+		comments = strings.Split(info.Comment, "\n")
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%s%c %d %d %d %d %d 0 %d %d\n", signature, hardblank, height, baseline,
+		maxWidth+info.Spacing+2, oldLayout, len(comments), fullLayout, len(tagged))
+	for _, line := range comments {
+		fmt.Fprintln(bw, line)
+	}
+
+	writeGlyph := func(r rune) {
+		rows, ok := font.Glyph(r)
+		spacing := strings.Repeat(" ", info.Spacing)
+		if !ok {
+			rows, spacing = make([]string, height), ""
+		}
+		for i, row := range rows {
+			fmt.Fprintf(bw, "%s%s%c", row, spacing, endmark)
+			if i == len(rows)-1 {
+				bw.WriteRune(endmark)
+			}
+			bw.WriteByte('\n')
+		}
+	}
+	for _, r := range order {
+		writeGlyph(r)
+	}
+	for _, r := range tagged {
+		fmt.Fprintf(bw, "%d  U+%04X\n", r, r)
+		writeGlyph(r)
+	}
+	return bw.Flush()
+}
+
+// figletLayoutValues converts a layout to the old and full layout values
+// of a FIGlet header
+func figletLayoutValues(layout Layout, rules SmushRule) (int, int) {
+	rules &= SmushAll
+	switch layout {
+	case LayoutKerning:
+		return 0, int(rules) | 64
+	case LayoutSmushing:
+		if rules == 0 {
+			// Universal smushing has no old layout value
+			return 0, 128
+		}
+		return int(rules), int(rules) | 128
+	default:
+		return -1, int(rules)
+	}
+}
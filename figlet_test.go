@@ -0,0 +1,168 @@
+package gofig
+
+import (
+	"strings"
+	"testing"
+)
+
+// standardGlyphs holds a few glyphs of the FIGlet standard font
+var standardGlyphs = map[rune][]string{
+	'H': {" _   _ @", "| | | |@", "| |_| |@", "|  _  |@", "|_| |_|@", "       @@"},
+	'e': {"      @", "  ___ @", " / _ \\@", "|  __/@", " \\___|@", "      @@"},
+	'l': {" _ @", "| |@", "| |@", "| |@", "|_|@", "   @@"},
+	'o': {"       @", "  ___  @", " / _ \\ @", "| (_) |@", " \\___/ @", "       @@"},
+}
+
+// testFIGletFont builds a FIGlet font file with the given header, the
+// glyphs from standardGlyphs and empty glyphs for all other required
+// characters, followed by extra lines such as code-tagged glyphs
+func testFIGletFont(header string, comments []string, extra ...string) string {
+	lines := append([]string{header}, comments...)
+	var required []rune
+	for ch := rune(32); ch <= 126; ch++ {
+		required = append(required, ch)
+	}
+	for _, ch := range append(required, figletDeutsch...) {
+		rows, ok := standardGlyphs[ch]
+		if !ok {
+			rows = []string{"@", "@", "@", "@", "@", "@@"}
+		}
+		lines = append(lines, rows...)
+	}
+	lines = append(lines, extra...)
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func TestParseFIGletHeader(t *testing.T) {
+	tests := []struct {
+		header   string
+		height   int
+		baseline int
+		comments int
+		layout   Layout
+		rules    SmushRule
+		wantErr  bool
+	}{
+		{header: "flf2a$ 6 5 16 15 2 0 24463", height: 6, baseline: 5, comments: 2,
+			layout: LayoutSmushing, rules: SmushEqual | SmushUnderscore | SmushHierarchy | SmushPair},
+		{header: "flf2a$ 6 5 16 15 1", height: 6, baseline: 5, comments: 1,
+			layout: LayoutSmushing, rules: SmushEqual | SmushUnderscore | SmushHierarchy | SmushPair},
+		{header: "flf2a$ 4 3 10 0 0", height: 4, baseline: 3, layout: LayoutKerning},
+		{header: "flf2a$ 4 3 10 -1 0", height: 4, baseline: 3, layout: LayoutFullWidth},
+		{header: "tlf2a$ 4 3 10 -1 0 0 128", height: 4, baseline: 3, layout: LayoutSmushing},
+		{header: "flf2a$ 4 3 10", wantErr: true},
+		{header: "flf2a$ 0 3 10 -1 0", wantErr: true},
+		{header: "xyz2a$ 4 3 10 -1 0", wantErr: true},
+		{header: "flf2a$ four 3 10 -1 0", wantErr: true},
+	}
+	for _, tt := range tests {
+		font, comments, err := parseFIGletHeader(tt.header)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseFIGletHeader(%q): expected an error", tt.header)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseFIGletHeader(%q): %v", tt.header, err)
+			continue
+		}
+		info := font.Info()
+		if font.Height() != tt.height || info.Baseline != tt.baseline || comments != tt.comments {
+			t.Errorf("parseFIGletHeader(%q): height %d, baseline %d, comments %d; want %d, %d, %d",
+				tt.header, font.Height(), info.Baseline, comments, tt.height, tt.baseline, tt.comments)
+		}
+		if info.Hardblank != '$' {
+			t.Errorf("parseFIGletHeader(%q): hardblank %q, want '$'", tt.header, info.Hardblank)
+		}
+		if info.Layout != tt.layout || info.SmushRules != tt.rules {
+			t.Errorf("parseFIGletHeader(%q): layout %d, rules %d; want %d, %d",
+				tt.header, info.Layout, info.SmushRules, tt.layout, tt.rules)
+		}
+	}
+}
+
+func TestParseFIGletFont(t *testing.T) {
+	data := testFIGletFont("flf2a$ 6 5 16 15 2 0 24463 2", []string{"first comment", "second comment"},
+		// Code tags in decimal, hex and octal, with and without a name
+		"256  LATIN CAPITAL LETTER A WITH MACRON",
+		"a@", "b@", "c@", "d@", "e@", "f@@",
+		"0x263A",
+		"1@", "2@", "3@", "4@", "5@", "6@@",
+		"",
+		"0100",
+		"x@", "x@", "x@", "x@", "x@", "x@@",
+		// Negative codes belong to translation tables
+		"-1  table",
+		"@", "@", "@", "@", "@", "@@",
+	)
+	font, err := ParseFIGletFont(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := font.Info().Comment; got != "first comment\nsecond comment" {
+		t.Errorf("comment %q", got)
+	}
+
+	tests := []struct {
+		r    rune
+		want []string
+	}{
+		{'H', []string{" _   _ ", "| | | |", "| |_| |", "|  _  |", "|_| |_|", "       "}},
+		{'l', []string{" _ ", "| |", "| |", "| |", "|_|", "   "}},
+		{'Ā', []string{"a", "b", "c", "d", "e", "f"}},
+		{'☺', []string{"1", "2", "3", "4", "5", "6"}},
+		{'@', []string{"x", "x", "x", "x", "x", "x"}},
+	}
+	for _, tt := range tests {
+		rows, ok := font.Glyph(tt.r)
+		if !ok {
+			t.Errorf("glyph %q missing", tt.r)
+			continue
+		}
+		if strings.Join(rows, "\n") != strings.Join(tt.want, "\n") {
+			t.Errorf("glyph %q = %q, want %q", tt.r, rows, tt.want)
+		}
+	}
+}
+
+func TestParseFIGletFontErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"empty", ""},
+		{"bad header", "flf2a$ 6\n"},
+		{"missing comments", "flf2a$ 6 5 16 15 3\nonly one\n"},
+		{"truncated glyphs", "flf2a$ 2 1 4 -1 0\n@\n@@\n@\n"},
+		{"bad code tag", testFIGletFont("flf2a$ 6 5 16 15 0", nil, "abc", "@", "@", "@", "@", "@", "@@")},
+		{"truncated code tag", testFIGletFont("flf2a$ 6 5 16 15 0", nil, "300", "@", "@")},
+	}
+	for _, tt := range tests {
+		if _, err := ParseFIGletFont(strings.NewReader(tt.data)); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+}
+
+func TestTrimFIGletLine(t *testing.T) {
+	tests := []struct {
+		line, want string
+	}{
+		{"| |@", "| |"},
+		{"|_|@@", "|_|"},
+		{"  _ #", "  _ "},
+		{"ab##", "ab"},
+		{"| |@  \r", "| |"},
+		{"@", ""},
+		{"@@", ""},
+		{"", ""},
+		{"   ", ""},
+		{"█ █é", "█ █"},
+	}
+	for _, tt := range tests {
+		if got := trimFIGletLine(tt.line); got != tt.want {
+			t.Errorf("trimFIGletLine(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
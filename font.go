@@ -0,0 +1,206 @@
+package gofig
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// Font is a source of glyphs for BlockFont.
+// Glyph rows use █ for filled cells and space for empty ones; any other
+// character is drawn as is.
+type Font interface {
+	// Glyph returns the rows of the glyph for r
+	Glyph(r rune) ([]string, bool)
+	// Height returns the number of rows in every glyph
+	Height() int
+	// Width returns the number of columns in the glyph for r
+	Width(r rune) int
+	// MaxWidth returns the width of the widest glyph
+	MaxWidth() int
+	// Runes returns the runes the font has glyphs for, in ascending order
+	Runes() []rune
+	// Info returns font metadata
+	Info() FontInfo
+}
+
+// FontInfo describes a font
+type FontInfo struct {
+	// Name is a short human-readable font name
+	Name string
+	// Comment is free-form text such as author or license
+	Comment string
+	// Spacing is the number of empty columns placed after every glyph
+	Spacing int
+	// Baseline is the number of rows from the top to the baseline
+	// (0 = the bottom row)
+	Baseline int
+	// Hardblank is drawn as a space but counts as a solid cell (0 = none)
+	Hardblank rune
+	// Layout is the preferred way to fit glyphs together
+	Layout Layout
+	// SmushRules are the preferred smushing rules
+	SmushRules SmushRule
+}
+
+// BitmapFont is a Font backed by an in-memory glyph table
+type BitmapFont struct {
+	info   FontInfo
+	height int
+	chars  map[rune][]string
+}
+
+// NewBitmapFont creates an empty font with the given glyph height
+func NewBitmapFont(info FontInfo, height int) *BitmapFont {
+	if height < 1 {
+		height = 1
+	}
+	return &BitmapFont{
+		info:   info,
+		height: height,
+		chars:  make(map[rune][]string),
+	}
+}
+
+// SetGlyph adds or replaces the glyph for r.
+// Rows shorter than the widest one are padded with spaces.
+func (f *BitmapFont) SetGlyph(r rune, rows []string) error {
+	if len(rows) != f.height {
+		return fmt.Errorf("gofig: glyph %q has %d rows, font height is %d", r, len(rows), f.height)
+	}
+	f.chars[r] = padRows(append([]string(nil), rows...))
+	return nil
+}
+
+// Glyph returns the rows of the glyph for r
+func (f *BitmapFont) Glyph(r rune) ([]string, bool) {
+	rows, ok := f.chars[r]
+	return rows, ok
+}
+
+// Height returns the number of rows in every glyph
+func (f *BitmapFont) Height() int {
+	return f.height
+}
+
+// Width returns the number of columns in the glyph for r (0 if missing)
+func (f *BitmapFont) Width(r rune) int {
+	rows, ok := f.chars[r]
+	if !ok || len(rows) == 0 {
+		return 0
+	}
+	return utf8.RuneCountInString(rows[0])
+}
+
+// MaxWidth returns the width of the widest glyph
+func (f *BitmapFont) MaxWidth() int {
+	width := 0
+	for r := range f.chars {
+		if w := f.Width(r); w > width {
+			width = w
+		}
+	}
+	return width
+}
+
+// Runes returns the runes the font has glyphs for, in ascending order
+func (f *BitmapFont) Runes() []rune {
+	runes := make([]rune, 0, len(f.chars))
+	for r := range f.chars {
+		runes = append(runes, r)
+	}
+	slices.Sort(runes)
+	return runes
+}
+
+// Info returns font metadata
+func (f *BitmapFont) Info() FontInfo {
+	return f.info
+}
+
+// DefaultFont returns a copy of the built-in block font.
+// Glyphs are 5 rows high and up to 5 columns wide; narrow characters
+// such as '!' or '.' are trimmed to their visible width.
+func DefaultFont() *BitmapFont {
+	f := NewBitmapFont(FontInfo{
+		Name:       "block",
+		Spacing:    1,
+		Baseline:   5,
+		Layout:     LayoutFullWidth,
+		SmushRules: SmushAll,
+	}, 5)
+	initChars(f.chars)
+	initLowercaseChars(f.chars)
+	initCyrillicChars(f.chars)
+	for r, rows := range f.chars {
+		if r != ' ' {
+			f.chars[r] = trimRows(rows)
+		}
+	}
+	return f
+}
+
+// padRows pads all rows with spaces to the width of the widest one
+func padRows(rows []string) []string {
+	width := 0
+	for _, row := range rows {
+		if w := utf8.RuneCountInString(row); w > width {
+			width = w
+		}
+	}
+	for i, row := range rows {
+		if w := utf8.RuneCountInString(row); w < width {
+			rows[i] = row + strings.Repeat(" ", width-w)
+		}
+	}
+	return rows
+}
+
+// trimRows removes empty columns on both sides of a glyph
+func trimRows(rows []string) []string {
+	grid := make([][]rune, len(rows))
+	left, right := -1, -1
+	for i, row := range rows {
+		grid[i] = []rune(row)
+		for x, ch := range grid[i] {
+			if ch == ' ' {
+				continue
+			}
+			if left < 0 || x < left {
+				left = x
+			}
+			if x > right {
+				right = x
+			}
+		}
+	}
+	if left < 0 {
+		return rows
+	}
+
+	trimmed := make([]string, len(rows))
+	for i, row := range grid {
+		if right < len(row) {
+			trimmed[i] = string(row[left : right+1])
+		} else {
+			trimmed[i] = string(row[left:])
+		}
+	}
+	return trimmed
+}
+
+// centerRows pads a glyph with spaces on both sides to the given width
+func centerRows(rows []string, width int) []string {
+	centered := make([]string, len(rows))
+	for i, row := range rows {
+		extra := width - utf8.RuneCountInString(row)
+		if extra <= 0 {
+			centered[i] = row
+			continue
+		}
+		left := extra / 2
+		centered[i] = strings.Repeat(" ", left) + row + strings.Repeat(" ", extra-left)
+	}
+	return centered
+}
@@ -0,0 +1,353 @@
+package gofig
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// fontSignature starts every gofig font file
+const fontSignature = "gofig font 1"
+
+// fontLayoutNames maps layout names used in font files to layouts
+var fontLayoutNames = map[string]Layout{
+	"default": LayoutDefault,
+	"full":    LayoutFullWidth,
+	"kern":    LayoutKerning,
+	"smush":   LayoutSmushing,
+}
+
+// smushRuleNames lists the names of smushing rules in bit order
+var smushRuleNames = []string{"equal", "underscore", "hierarchy", "pair", "bigx", "hardblank"}
+
+// LoadFont reads a font in the gofig font format from a file.
+// See WriteFont for a description of the format.
+func LoadFont(path string) (*BitmapFont, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	font, err := ParseFont(file)
+	if err != nil {
+		return nil, err
+	}
+	if font.info.Name == "" {
+		font.info.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	return font, nil
+}
+
+// ParseFont reads a font in the gofig font format from r
+func ParseFont(r io.Reader) (*BitmapFont, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNum := 0
+	next := func() (string, bool) {
+		if !scanner.Scan() {
+			return "", false
+		}
+		lineNum++
+		return strings.TrimSuffix(scanner.Text(), "\r"), true
+	}
+	fail := func(format string, args ...any) error {
+		return fmt.Errorf("gofig: font line %d: %s", lineNum, fmt.Sprintf(format, args...))
+	}
+
+	line, ok := next()
+	if !ok || strings.TrimSpace(line) != fontSignature {
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("gofig: not a gofig font (expected %q)", fontSignature)
+	}
+
+	// Header: "key: value" lines up to the first glyph
+	var info FontInfo
+	var comments []string
+	height := 0
+	fill, blank := '#', '.'
+	for {
+		line, ok = next()
+		if !ok {
+			return nil, fail("font has no glyphs")
+		}
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "glyph ") {
+			break
+		}
+		key, value, found := strings.Cut(trimmed, ":")
+		if !found {
+			return nil, fail("expected \"key: value\", got %q", trimmed)
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+
+		var err error
+		switch key {
+		case "name":
+			info.Name = value
+		case "comment":
+			comments = append(comments, value)
+		case "height":
+			height, err = strconv.Atoi(value)
+			if err == nil && height < 1 {
+				err = fmt.Errorf("height must be positive")
+			}
+		case "baseline":
+			info.Baseline, err = strconv.Atoi(value)
+		case "spacing":
+			info.Spacing, err = strconv.Atoi(value)
+		case "layout":
+			layout, known := fontLayoutNames[value]
+			if !known {
+				err = fmt.Errorf("unknown layout %q", value)
+			}
+			info.Layout = layout
+		case "smush":
+			info.SmushRules, err = parseSmushRules(value)
+		case "hardblank", "fill", "blank":
+			var r rune
+			r, err = singleRune(value)
+			switch key {
+			case "hardblank":
+				info.Hardblank = r
+			case "fill":
+				fill = r
+			default:
+				blank = r
+			}
+		default:
+			err = fmt.Errorf("unknown key %q", key)
+		}
+		if err != nil {
+			return nil, fail("%s: %v", key, err)
+		}
+	}
+	if height == 0 {
+		return nil, fail("height is missing from the header")
+	}
+	info.Comment = strings.Join(comments, "\n")
+	font := NewBitmapFont(info, height)
+
+	// Glyphs: "glyph <rune>" followed by one line per row
+	for {
+		key := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "glyph "))
+		r, err := parseGlyphKey(key)
+		if err != nil {
+			return nil, fail("%v", err)
+		}
+		rows := make([]string, height)
+		for i := range rows {
+			row, ok := next()
+			if !ok {
+				return nil, fail("glyph %q: unexpected end of file", r)
+			}
+			rows[i] = strings.Map(func(ch rune) rune {
+				switch ch {
+				case fill:
+					return '█'
+				case blank:
+					return ' '
+				}
+				return ch
+			}, row)
+		}
+		font.chars[r] = padRows(rows)
+
+		// Skip blank lines up to the next glyph
+		for {
+			line, ok = next()
+			if !ok {
+				return font, scanner.Err()
+			}
+			if strings.TrimSpace(line) != "" {
+				break
+			}
+		}
+		if !strings.HasPrefix(strings.TrimSpace(line), "glyph ") {
+			return nil, fail("expected \"glyph\", got %q", line)
+		}
+	}
+}
+
+// parseSmushRules parses a comma separated list of smushing rule names
+func parseSmushRules(value string) (SmushRule, error) {
+	var rules SmushRule
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || name == "universal" {
+			continue
+		}
+		i := 0
+		for i < len(smushRuleNames) && smushRuleNames[i] != name {
+			i++
+		}
+		if i == len(smushRuleNames) {
+			return 0, fmt.Errorf("unknown rule %q", name)
+		}
+		rules |= 1 << i
+	}
+	return rules, nil
+}
+
+// parseGlyphKey parses a glyph key: a single character or U+XXXX
+func parseGlyphKey(key string) (rune, error) {
+	if hex, ok := strings.CutPrefix(key, "U+"); ok {
+		code, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil || code > utf8.MaxRune {
+			return 0, fmt.Errorf("invalid code point %q", key)
+		}
+		return rune(code), nil
+	}
+	return singleRune(key)
+}
+
+// singleRune returns the only rune of s
+func singleRune(s string) (rune, error) {
+	r, size := utf8.DecodeRuneInString(s)
+	if s == "" || size != len(s) {
+		return 0, fmt.Errorf("expected a single character, got %q", s)
+	}
+	return r, nil
+}
+
+// SaveFont writes a font to a file in the gofig font format
+func SaveFont(path string, font Font) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := WriteFont(file, font); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// WriteFont writes any font in the gofig font format, a plain text format
+// meant to be edited by hand:
+//
+//	gofig font 1
+//	name: block
+//	height: 5
+//	spacing: 1
+//	fill: #
+//	blank: .
+//
+//	glyph A
+//	.###.
+//	#...#
+//	#####
+//	#...#
+//	#...#
+//
+// The header holds "key: value" lines: name, comment (repeatable), height,
+// baseline, spacing, layout (default, full, kern, smush), smush (comma
+// separated rules: equal, underscore, hierarchy, pair, bigx, hardblank),
+// hardblank, fill and blank. Glyphs follow, each keyed by the character or
+// by U+XXXX and followed by exactly height rows; shorter rows are padded
+// with empty cells. In rows the fill character
+// (default '#') is a filled cell, the blank character (default '.') an
+// empty one and any other character is drawn as is.
+func WriteFont(w io.Writer, font Font) error {
+	info := font.Info()
+	runes := font.Runes()
+
+	// Fill and blank must not clash with characters drawn as is
+	used := map[rune]bool{info.Hardblank: true}
+	for _, r := range runes {
+		rows, _ := font.Glyph(r)
+		for _, row := range rows {
+			for _, ch := range row {
+				used[ch] = true
+			}
+		}
+	}
+	fill, err := unusedRune("#X@%*&=+", used)
+	if err != nil {
+		return err
+	}
+	blank, err := unusedRune(".-:~',`", used)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, fontSignature)
+	if info.Name != "" {
+		fmt.Fprintf(bw, "name: %s\n", info.Name)
+	}
+	if info.Comment != "" {
+		for _, line := range strings.Split(info.Comment, "\n") {
+			fmt.Fprintf(bw, "comment: %s\n", line)
+		}
+	}
+	fmt.Fprintf(bw, "height: %d\n", font.Height())
+	if info.Baseline != 0 {
+		fmt.Fprintf(bw, "baseline: %d\n", info.Baseline)
+	}
+	fmt.Fprintf(bw, "spacing: %d\n", info.Spacing)
+	for name, layout := range fontLayoutNames {
+		if layout == info.Layout && layout != LayoutDefault {
+			fmt.Fprintf(bw, "layout: %s\n", name)
+		}
+	}
+	if info.SmushRules != 0 {
+		var names []string
+		for i, name := range smushRuleNames {
+			if info.SmushRules&(1<<i) != 0 {
+				names = append(names, name)
+			}
+		}
+		fmt.Fprintf(bw, "smush: %s\n", strings.Join(names, ","))
+	}
+	if info.Hardblank != 0 {
+		fmt.Fprintf(bw, "hardblank: %c\n", info.Hardblank)
+	}
+	fmt.Fprintf(bw, "fill: %c\nblank: %c\n", fill, blank)
+
+	for _, r := range runes {
+		rows, _ := font.Glyph(r)
+		fmt.Fprintf(bw, "\nglyph %s\n", glyphKey(r))
+		for _, row := range rows {
+			fmt.Fprintln(bw, strings.Map(func(ch rune) rune {
+				switch ch {
+				case '█':
+					return fill
+				case ' ':
+					return blank
+				}
+				return ch
+			}, row))
+		}
+	}
+	return bw.Flush()
+}
+
+// glyphKey returns the key a glyph is written under
+func glyphKey(r rune) string {
+	if unicode.IsGraphic(r) && !unicode.IsSpace(r) {
+		return string(r)
+	}
+	return fmt.Sprintf("U+%04X", r)
+}
+
+// unusedRune returns the first candidate not in used
+func unusedRune(candidates string, used map[rune]bool) (rune, error) {
+	for _, r := range candidates {
+		if !used[r] {
+			return r, nil
+		}
+	}
+	return 0, fmt.Errorf("gofig: no free character among %q to write the font with", candidates)
+}
@@ -0,0 +1,191 @@
+package gofig
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// sameGlyphs fails the test at the first rune whose glyph differs
+// between two fonts
+func sameGlyphs(t *testing.T, got, want Font) {
+	t.Helper()
+	if !slices.Equal(got.Runes(), want.Runes()) {
+		t.Fatalf("runes %q, want %q", string(got.Runes()), string(want.Runes()))
+	}
+	for _, r := range want.Runes() {
+		g, _ := got.Glyph(r)
+		w, _ := want.Glyph(r)
+		if !slices.Equal(g, w) {
+			t.Fatalf("glyph %q = %q, want %q", r, g, w)
+		}
+	}
+}
+
+// testFonts returns fonts to round trip: the built-in font, a FIGlet font
+// and a font drawn with characters the gofig format uses itself
+func testFonts(t *testing.T) map[string]Font {
+	figlet, err := ParseFIGletFont(strings.NewReader(testFIGletFont("flf2a$ 6 5 16 15 1 0 24463", []string{"standard"})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	literal := NewBitmapFont(FontInfo{Name: "literal", Comment: "two\nlines", Spacing: 2, Layout: LayoutKerning}, 2)
+	literal.SetGlyph('a', []string{"#.", ".#"})
+	literal.SetGlyph(' ', []string{"  ", "  "})
+	literal.SetGlyph('\t', []string{"█ ", " █"})
+	return map[string]Font{"default": DefaultFont(), "figlet": figlet, "literal": literal}
+}
+
+func TestFontRoundTrip(t *testing.T) {
+	for name, font := range testFonts(t) {
+		var buf bytes.Buffer
+		if err := WriteFont(&buf, font); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		parsed, err := ParseFont(&buf)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if parsed.Info() != font.Info() || parsed.Height() != font.Height() {
+			t.Errorf("%s: info %+v height %d, want %+v height %d",
+				name, parsed.Info(), parsed.Height(), font.Info(), font.Height())
+		}
+		sameGlyphs(t, parsed, font)
+	}
+}
+
+func TestFIGletFontRoundTrip(t *testing.T) {
+	for name, font := range testFonts(t) {
+		var buf bytes.Buffer
+		if err := WriteFIGletFont(&buf, font); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		parsed, err := ParseFIGletFont(&buf)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		// FIGlet glyphs carry their own spacing, the name comes from the
+		// file and the header needs a hardblank and baseline
+		want := font.Info()
+		got := parsed.Info()
+		if want.Hardblank == 0 {
+			want.Hardblank = '$'
+		}
+		if want.Baseline == 0 {
+			want.Baseline = font.Height()
+		}
+		want.Name, want.Spacing = "", 0
+		if got != want || parsed.Height() != font.Height() {
+			t.Errorf("%s: info %+v height %d, want %+v height %d",
+				name, got, parsed.Height(), want, font.Height())
+		}
+
+		// Required characters missing from the font are written empty
+		runes := font.Runes()
+		for _, r := range parsed.Runes() {
+			rows, _ := parsed.Glyph(r)
+			if !slices.Contains(runes, r) && rows[0] != "" {
+				t.Errorf("%s: glyph %q = %q, want empty", name, r, rows)
+			}
+		}
+		for _, r := range runes {
+			got, _ := parsed.Glyph(r)
+			rows, _ := font.Glyph(r)
+			for i := range rows {
+				rows[i] += strings.Repeat(" ", font.Info().Spacing)
+			}
+			if !slices.Equal(got, rows) {
+				t.Errorf("%s: glyph %q = %q, want %q", name, r, got, rows)
+			}
+		}
+	}
+}
+
+func TestParseFont(t *testing.T) {
+	data := `gofig font 1
+name: tiny
+comment: first
+comment: second
+height: 2
+baseline: 1
+spacing: 0
+layout: smush
+smush: equal, bigx
+hardblank: $
+fill: *
+blank: -
+
+glyph A
+*-*
+-*
+
+glyph U+263A
+ab
+
+glyph #
+**
+**
+`
+	font, err := ParseFont(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := FontInfo{
+		Name:       "tiny",
+		Comment:    "first\nsecond",
+		Baseline:   1,
+		Layout:     LayoutSmushing,
+		SmushRules: SmushEqual | SmushBigX,
+		Hardblank:  '$',
+	}
+	if font.Info() != want {
+		t.Errorf("info %+v, want %+v", font.Info(), want)
+	}
+
+	tests := []struct {
+		r    rune
+		want []string
+	}{
+		// Short rows are padded with empty cells
+		{'A', []string{"█ █", " █ "}},
+		// Blank lines inside a glyph are rows, other characters are kept
+		{'☺', []string{"ab", "  "}},
+		{'#', []string{"██", "██"}},
+	}
+	for _, tt := range tests {
+		rows, ok := font.Glyph(tt.r)
+		if !ok || !slices.Equal(rows, tt.want) {
+			t.Errorf("glyph %q = %q, want %q", tt.r, rows, tt.want)
+		}
+	}
+}
+
+func TestParseFontErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"empty", ""},
+		{"signature", "gofig font 2\nheight: 1\nglyph A\n#\n"},
+		{"no height", "gofig font 1\nglyph A\n#\n"},
+		{"zero height", "gofig font 1\nheight: 0\nglyph A\n#\n"},
+		{"bad height", "gofig font 1\nheight: x\nglyph A\n#\n"},
+		{"unknown key", "gofig font 1\nheight: 1\ncolor: red\nglyph A\n#\n"},
+		{"no colon", "gofig font 1\nheight 1\nglyph A\n#\n"},
+		{"layout", "gofig font 1\nheight: 1\nlayout: tight\nglyph A\n#\n"},
+		{"smush rule", "gofig font 1\nheight: 1\nsmush: equal,magic\nglyph A\n#\n"},
+		{"fill", "gofig font 1\nheight: 1\nfill: ##\nglyph A\n#\n"},
+		{"no glyphs", "gofig font 1\nheight: 1\n"},
+		{"glyph key", "gofig font 1\nheight: 1\nglyph AB\n#\n"},
+		{"code point", "gofig font 1\nheight: 1\nglyph U+XYZ\n#\n"},
+		{"truncated", "gofig font 1\nheight: 2\nglyph A\n#\n"},
+		{"extra row", "gofig font 1\nheight: 1\nglyph A\n#\n#\n"},
+	}
+	for _, tt := range tests {
+		if _, err := ParseFont(strings.NewReader(tt.data)); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package gofig
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// ParseGlyph parses a glyph drawn as text, one row per line. '#', 'X',
+// '1' and '█' are filled cells; '.', '0' and space are empty. A newline at
+// the start or end is ignored, so raw string literals can be used as is.
+func ParseGlyph(bitmap string) ([]string, error) {
+	bitmap = strings.TrimPrefix(bitmap, "\n")
+	bitmap = strings.TrimSuffix(bitmap, "\n")
+	lines := strings.Split(bitmap, "\n")
+
+	rows := make([]string, len(lines))
+	for y, line := range lines {
+		var row strings.Builder
+		for x, ch := range strings.TrimSuffix(line, "\r") {
+			switch ch {
+			case '#', 'X', '1', '█':
+				row.WriteRune('█')
+			case '.', '0', ' ':
+				row.WriteRune(' ')
+			default:
+				return nil, fmt.Errorf("gofig: glyph row %d, column %d: unexpected %q", y+1, x+1, ch)
+			}
+		}
+		rows[y] = row.String()
+	}
+	return rows, validateGlyph(rows)
+}
+
+// GlyphFromBitmap converts a grid of filled cells into glyph rows
+func GlyphFromBitmap(bitmap [][]bool) ([]string, error) {
+	rows := make([]string, len(bitmap))
+	for y, cells := range bitmap {
+		var row strings.Builder
+		for _, filled := range cells {
+			if filled {
+				row.WriteRune('█')
+			} else {
+				row.WriteRune(' ')
+			}
+		}
+		rows[y] = row.String()
+	}
+	return rows, validateGlyph(rows)
+}
+
+// validateGlyph checks that a glyph has rows of the same non-zero width
+func validateGlyph(rows []string) error {
+	if len(rows) == 0 {
+		return fmt.Errorf("gofig: glyph has no rows")
+	}
+	width := utf8.RuneCountInString(rows[0])
+	if width == 0 {
+		return fmt.Errorf("gofig: glyph has no columns")
+	}
+	for y, row := range rows {
+		if w := utf8.RuneCountInString(row); w != width {
+			return fmt.Errorf("gofig: glyph row %d is %d wide, row 1 is %d", y+1, w, width)
+		}
+	}
+	return nil
+}
+
+// DefineGlyph adds or replaces the glyph for r, drawn as text in the
+// syntax of ParseGlyph. The glyph must be as high as the font. A glyph
+// for a lowercase rune is drawn even without Config.PreserveCase.
+func (bf *BlockFont) DefineGlyph(r rune, bitmap string) error {
+	rows, err := ParseGlyph(bitmap)
+	if err != nil {
+		return err
+	}
+	return bf.defineGlyph(r, rows)
+}
+
+// DefineGlyphBitmap adds or replaces the glyph for r from a grid of
+// filled cells. The glyph must be as high as the font.
+func (bf *BlockFont) DefineGlyphBitmap(r rune, bitmap [][]bool) error {
+	rows, err := GlyphFromBitmap(bitmap)
+	if err != nil {
+		return err
+	}
+	return bf.defineGlyph(r, rows)
+}
+
+// defineGlyph stores validated glyph rows for r
+func (bf *BlockFont) defineGlyph(r rune, rows []string) error {
+	if height := bf.font.Height(); len(rows) != height {
+		return fmt.Errorf("gofig: glyph %q has %d rows, font height is %d", r, len(rows), height)
+	}
+	bf.glyphs[r] = rows
+	bf.cellWidth = 0
+	return nil
+}
+
+// RemoveGlyph removes a glyph defined at runtime, so the font's own glyph
+// for r is drawn again
+func (bf *BlockFont) RemoveGlyph(r rune) {
+	delete(bf.glyphs, r)
+	bf.cellWidth = 0
+}
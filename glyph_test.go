@@ -0,0 +1,41 @@
+package gofig
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDefineGlyphCase(t *testing.T) {
+	bf := New()
+	bf.SetColor(NoColor)
+	bf.config.ColorProfile = ProfileNone
+	upper := bf.Render("A")
+
+	if err := bf.DefineGlyph('a', "#\n#\n#\n#\n#"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := bf.Render("a"), strings.Repeat("█\n", 4)+"█"; got != want {
+		t.Errorf("Render(\"a\") =\n%s\nwant the defined glyph", got)
+	}
+	// The uppercase rune keeps the font's glyph
+	if got := bf.Render("A"); got != upper {
+		t.Errorf("Render(\"A\") =\n%s\nwant\n%s", got, upper)
+	}
+}
+
+func TestMonospaceRuntimeGlyph(t *testing.T) {
+	config := DefaultConfig()
+	config.Monospace = true
+	bf := NewWithConfig(config)
+	if err := bf.DefineGlyph('~', ".......\n.#...#.\n#.#.#.#\n...#...\n......."); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := bf.width('I'), bf.width('~'); got != want || got != 7 {
+		t.Errorf("widths of 'I' and '~' are %d and %d, want 7", got, want)
+	}
+
+	bf.RemoveGlyph('~')
+	if got, want := bf.width('I'), bf.font.MaxWidth(); got != want {
+		t.Errorf("width of 'I' after RemoveGlyph is %d, want %d", got, want)
+	}
+}
@@ -1,53 +1,112 @@
 package gofig
 
-import (
-	"strings"
-)
-
-// ANSI color codes
-const (
-	ColorReset   = "\033[0m"
-	ColorRed     = "\033[31m"
-	ColorGreen   = "\033[32m"
-	ColorYellow  = "\033[33m"
-	ColorBlue    = "\033[34m"
-	ColorMagenta = "\033[35m"
-	ColorCyan    = "\033[36m"
-	ColorWhite   = "\033[37m"
-	ColorBrightRed     = "\033[91m"
-	ColorBrightGreen   = "\033[92m"
-	ColorBrightYellow  = "\033[93m"
-	ColorBrightBlue    = "\033[94m"
-	ColorBrightMagenta = "\033[95m"
-	ColorBrightCyan    = "\033[96m"
-)
+import "unicode/utf8"
 
 // Config holds settings for block text rendering
 type Config struct {
 	// Scale multiplies the size (1 = normal, 2 = double, etc.)
 	Scale int
+	// ScaleX and ScaleY scale each axis on its own (0 = Scale)
+	ScaleX, ScaleY int
 	// Char is the block character to use (default: █)
 	Char string
 	// Space is the space character (default: space)
 	Space string
-	// Color is the ANSI color code (e.g., ColorGreen)
-	Color string
+	// Color is the text color (e.g., ColorGreen, RGB(255, 136, 0))
+	Color Color
+	// Background is the background color of text cells
+	Background Color
+	// SpaceColor is the color of the Space character in empty cells
+	SpaceColor Color
+	// SpaceBackground is the background color of empty cells,
+	// e.g. the panel of a badge
+	SpaceBackground Color
+	// Reverse swaps drawn and empty cells: text is cut out of a solid block
+	Reverse bool
+	// Gradient colors the text with blended colors instead of Color
+	Gradient Gradient
+	// ColorProfile limits colors to what the terminal supports (default: detect)
+	ColorProfile ColorProfile
+	// PreserveCase draws lowercase letters with lowercase glyphs when the
+	// font has them, instead of converting text to uppercase
+	PreserveCase bool
+	// Fallback selects what is drawn for runes no font has a glyph for
+	Fallback Fallback
+	// Substitutes maps runes without glyphs to runes drawn instead
+	Substitutes map[rune]rune
+	// Decompose draws Latin letters with diacritics as their base letters
+	// when the font lacks them (É as E, Ệ as E, Ø as O). It covers Latin
+	// letters up to U+024F and Latin Extended Additional.
+	Decompose bool
+	// FallbackFonts are searched in order for glyphs the font lacks
+	FallbackFonts []Font
+	// Monospace pads every glyph to the width of the widest one. The width
+	// is measured once; call SetFont again after changing the font's glyphs.
+	Monospace bool
+	// Layout fits glyphs together (default: the font's own layout)
+	Layout Layout
+	// SmushRules used with LayoutSmushing (default: the font's own rules)
+	SmushRules SmushRule
+	// Mode selects how glyph cells map to terminal characters
+	Mode RenderMode
+	// Shadow draws a drop shadow or 3D extrusion behind the glyphs
+	Shadow Shadow
+	// Transform slants, thickens, narrows, widens, mirrors or flips glyphs
+	Transform Transform
+	// Fill selects solid, outlined or hollow glyphs. Outlined and hollow
+	// glyphs are drawn at a scale of at least 3.
+	Fill FillStyle
+	// HollowChar draws the inside of glyphs with FillHollow (default: ░)
+	HollowChar string
+	// Align positions the lines of multi-line text
+	Align Align
+	// Orientation runs text horizontally, stacked vertically or rotated
+	Orientation Orientation
+	// LineSpacing is the number of empty glyph rows between lines of text
+	LineSpacing int
+	// MaxWidth wraps lines wider than this many terminal columns at word
+	// boundaries (0 = no wrapping, MaxWidthTerminal = the terminal width)
+	MaxWidth int
+	// Hyphenate adds '-' where a word too wide for a line is broken
+	Hyphenate bool
+	// Padding is the space between the text and the edge of its panel,
+	// drawn with the colors of empty cells
+	Padding Spacing
+	// Border is drawn between the padding and the margin
+	Border Border
+	// BorderColor is the color of the border and its title
+	BorderColor Color
+	// Title is shown in the top edge of the border
+	Title string
+	// TitleAlign positions the title in the top edge
+	TitleAlign Align
+	// Margin is the uncolored space around the panel
+	Margin Spacing
+	// Center centers output in a viewport of ViewWidth x ViewHeight
+	Center Center
+	// ViewWidth and ViewHeight are the viewport size (0 = terminal size)
+	ViewWidth, ViewHeight int
 }
 
 // DefaultConfig returns default configuration
 func DefaultConfig() Config {
 	return Config{
-		Scale: 1,
-		Char:  "█",
-		Space: " ",
+		Scale:       1,
+		Char:        "█",
+		Space:       " ",
+		LineSpacing: 1,
+		Hyphenate:   true,
 	}
 }
 
 // BlockFont generates large text using block characters
 type BlockFont struct {
 	config Config
-	chars  map[rune][]string
-	height int
+	font   Font
+	// glyphs are defined at runtime and take precedence over the font
+	glyphs map[rune][]string
+	// cellWidth caches the monospace cell width, 0 until computed
+	cellWidth int
 }
 
 // New creates a new block font with default config
@@ -57,9 +116,20 @@ func New() *BlockFont {
 
 // NewWithConfig creates a new block font with custom config
 func NewWithConfig(config Config) *BlockFont {
+	return NewWithFont(DefaultFont(), config)
+}
+
+// NewWithFont creates a block font that draws glyphs from font
+func NewWithFont(font Font, config Config) *BlockFont {
 	if config.Scale < 1 {
 		config.Scale = 1
 	}
+	if config.ScaleX < 1 {
+		config.ScaleX = config.Scale
+	}
+	if config.ScaleY < 1 {
+		config.ScaleY = config.Scale
+	}
 	if config.Char == "" {
 		config.Char = "█"
 	}
@@ -67,257 +137,334 @@ func NewWithConfig(config Config) *BlockFont {
 		config.Space = " "
 	}
 
-	bf := &BlockFont{
+	return &BlockFont{
 		config: config,
-		chars:  make(map[rune][]string),
-		height: 5,
+		font:   font,
+		glyphs: make(map[rune][]string),
 	}
-	bf.initChars()
-	return bf
 }
 
 // Render converts text to block characters
 func (bf *BlockFont) Render(text string) string {
-	text = strings.ToUpper(text)
-	lines := make([]string, bf.height*bf.config.Scale)
+	return bf.render(text, renderOptions{})
+}
 
-	for _, ch := range text {
-		pattern, ok := bf.chars[ch]
-		if !ok {
-			pattern = bf.chars[' ']
-		}
-		for i := 0; i < bf.height; i++ {
-			// Scale the line horizontally
-			scaledLine := bf.scaleLine(pattern[i])
-			// Repeat for vertical scaling
-			for s := 0; s < bf.config.Scale; s++ {
-				lines[i*bf.config.Scale+s] += scaledLine + strings.Repeat(bf.config.Space, bf.config.Scale)
-			}
+// SetColor sets the text color
+func (bf *BlockFont) SetColor(color Color) {
+	bf.config.Color = color
+}
+
+// SetBackground sets the background colors of text and empty cells
+func (bf *BlockFont) SetBackground(text, space Color) {
+	bf.config.Background = text
+	bf.config.SpaceBackground = space
+}
+
+// SetReverse draws the text as holes in a solid block when enabled
+func (bf *BlockFont) SetReverse(reverse bool) {
+	bf.config.Reverse = reverse
+}
+
+// Font returns the font glyphs are drawn from
+func (bf *BlockFont) Font() Font {
+	return bf.font
+}
+
+// SetFont changes the font glyphs are drawn from. Glyphs defined at
+// runtime are kept if they are as high as the new font.
+func (bf *BlockFont) SetFont(font Font) {
+	bf.font = font
+	bf.cellWidth = 0
+	for r, rows := range bf.glyphs {
+		if len(rows) != font.Height() {
+			delete(bf.glyphs, r)
 		}
 	}
+}
 
-	result := strings.Join(lines, "\n")
+// SetMonospace switches between proportional and monospace glyphs
+func (bf *BlockFont) SetMonospace(monospace bool) {
+	bf.config.Monospace = monospace
+}
 
-	// Apply color if set
-	if bf.config.Color != "" {
-		result = bf.config.Color + result + ColorReset
-	}
+// pattern returns the glyph rows for ch, falling back to Config.Fallback.
+// Letters are drawn in uppercase unless Config.PreserveCase is set.
+// In monospace mode the glyph is centered in a cell as wide as the widest
+// glyph that can be drawn.
+// Config.Transform is applied last.
+func (bf *BlockFont) pattern(ch rune) []string {
+	pattern, ok := bf.lookup(ch)
+	if !ok {
+		pattern = bf.missing()
+	}
+	if bf.config.Monospace {
+		pattern = centerRows(pattern, bf.monospaceWidth())
+	}
+	return bf.transform(pattern)
+}
 
-	return result
+// monospaceWidth returns the width of monospace cells: the widest glyph of
+// the font, its fallback fonts, the glyphs defined at runtime and the tofu
+// box. It is computed once and reset when the font or glyphs change.
+func (bf *BlockFont) monospaceWidth() int {
+	if bf.cellWidth > 0 {
+		return bf.cellWidth
+	}
+	width := bf.font.MaxWidth()
+	for _, font := range bf.config.FallbackFonts {
+		width = max(width, font.MaxWidth())
+	}
+	for _, rows := range bf.glyphs {
+		if len(rows) > 0 {
+			width = max(width, utf8.RuneCountInString(rows[0]))
+		}
+	}
+	if bf.config.Fallback == FallbackTofu {
+		width = max(width, utf8.RuneCountInString(tofu(1)[0]))
+	}
+	bf.cellWidth = width
+	return width
 }
 
-// SetColor sets the text color
-func (bf *BlockFont) SetColor(color string) {
-	bf.config.Color = color
+// width returns the width of the glyph drawn for ch
+func (bf *BlockFont) width(ch rune) int {
+	pattern := bf.pattern(ch)
+	if len(pattern) == 0 {
+		return 0
+	}
+	return utf8.RuneCountInString(pattern[0])
 }
 
-// scaleLine scales a single line horizontally and applies custom char
-func (bf *BlockFont) scaleLine(line string) string {
-	var result strings.Builder
-	for _, ch := range line {
-		char := bf.config.Space
-		if ch == '█' {
-			char = bf.config.Char
+// scaleLine scales a single line horizontally
+func (bf *BlockFont) scaleLine(line []cell) []cell {
+	scaleX, _ := bf.scaleFactors()
+	scaled := make([]cell, 0, len(line)*scaleX)
+	for _, c := range line {
+		for s := 0; s < scaleX; s++ {
+			scaled = append(scaled, c)
 		}
-		result.WriteString(strings.Repeat(char, bf.config.Scale))
 	}
-	return result.String()
+	return scaled
 }
 
-// SetScale changes the scale factor
+// scaleFactors returns the horizontal and vertical scale glyph cells are
+// drawn at: Config.ScaleX and Config.ScaleY, raised for fills that need room
+func (bf *BlockFont) scaleFactors() (x, y int) {
+	k := bf.fillScale()
+	return bf.config.ScaleX * k, bf.config.ScaleY * k
+}
+
+// SetScale changes the scale factor of both axes
 func (bf *BlockFont) SetScale(scale int) {
 	if scale < 1 {
 		scale = 1
 	}
 	bf.config.Scale = scale
+	bf.config.ScaleX = scale
+	bf.config.ScaleY = scale
+}
+
+// SetScaleXY changes the horizontal and vertical scale factors
+func (bf *BlockFont) SetScaleXY(x, y int) {
+	bf.config.ScaleX = max(x, 1)
+	bf.config.ScaleY = max(y, 1)
+}
+
+// SetAspectCorrect scales glyphs so their cells look square on terminals
+// whose character cells are about twice as tall as wide. scale sets the
+// vertical factor; the horizontal one follows from the render mode.
+func (bf *BlockFont) SetAspectCorrect(scale int) {
+	scale = max(scale, 1)
+	cw, ch := bf.config.Mode.cellSize()
+	bf.config.ScaleY = scale
+	bf.config.ScaleX = max(scale*2*cw/ch, 1)
 }
 
 // SetChar changes the block character
 func (bf *BlockFont) SetChar(char string) {
 	bf.config.Char = char
-	bf.initChars()
 }
 
-func (bf *BlockFont) initChars() {
+func initChars(chars map[rune][]string) {
 	b := "█"
 	s := " "
 
-	bf.chars['A'] = []string{
+	chars['A'] = []string{
 		s + b + b + b + s,
 		b + s + s + s + b,
 		b + b + b + b + b,
 		b + s + s + s + b,
 		b + s + s + s + b,
 	}
-	bf.chars['B'] = []string{
+	chars['B'] = []string{
 		b + b + b + b + s,
 		b + s + s + s + b,
 		b + b + b + b + s,
 		b + s + s + s + b,
 		b + b + b + b + s,
 	}
-	bf.chars['C'] = []string{
+	chars['C'] = []string{
 		s + b + b + b + b,
 		b + s + s + s + s,
 		b + s + s + s + s,
 		b + s + s + s + s,
 		s + b + b + b + b,
 	}
-	bf.chars['D'] = []string{
+	chars['D'] = []string{
 		b + b + b + b + s,
 		b + s + s + s + b,
 		b + s + s + s + b,
 		b + s + s + s + b,
 		b + b + b + b + s,
 	}
-	bf.chars['E'] = []string{
+	chars['E'] = []string{
 		b + b + b + b + b,
 		b + s + s + s + s,
 		b + b + b + b + s,
 		b + s + s + s + s,
 		b + b + b + b + b,
 	}
-	bf.chars['F'] = []string{
+	chars['F'] = []string{
 		b + b + b + b + b,
 		b + s + s + s + s,
 		b + b + b + b + s,
 		b + s + s + s + s,
 		b + s + s + s + s,
 	}
-	bf.chars['G'] = []string{
+	chars['G'] = []string{
 		s + b + b + b + b,
 		b + s + s + s + s,
 		b + s + b + b + b,
 		b + s + s + s + b,
 		s + b + b + b + s,
 	}
-	bf.chars['H'] = []string{
+	chars['H'] = []string{
 		b + s + s + s + b,
 		b + s + s + s + b,
 		b + b + b + b + b,
 		b + s + s + s + b,
 		b + s + s + s + b,
 	}
-	bf.chars['I'] = []string{
-		b + b + b + b + b,
-		s + s + b + s + s,
-		s + s + b + s + s,
-		s + s + b + s + s,
-		b + b + b + b + b,
+	chars['I'] = []string{
+		b + b + b,
+		s + b + s,
+		s + b + s,
+		s + b + s,
+		b + b + b,
 	}
-	bf.chars['J'] = []string{
+	chars['J'] = []string{
 		s + s + b + b + b,
 		s + s + s + b + s,
 		s + s + s + b + s,
 		b + s + s + b + s,
 		s + b + b + s + s,
 	}
-	bf.chars['K'] = []string{
+	chars['K'] = []string{
 		b + s + s + s + b,
 		b + s + s + b + s,
 		b + b + b + s + s,
 		b + s + s + b + s,
 		b + s + s + s + b,
 	}
-	bf.chars['L'] = []string{
+	chars['L'] = []string{
 		b + s + s + s + s,
 		b + s + s + s + s,
 		b + s + s + s + s,
 		b + s + s + s + s,
 		b + b + b + b + b,
 	}
-	bf.chars['M'] = []string{
+	chars['M'] = []string{
 		b + s + s + s + b,
 		b + b + s + b + b,
 		b + s + b + s + b,
 		b + s + s + s + b,
 		b + s + s + s + b,
 	}
-	bf.chars['N'] = []string{
+	chars['N'] = []string{
 		b + s + s + s + b,
 		b + b + s + s + b,
 		b + s + b + s + b,
 		b + s + s + b + b,
 		b + s + s + s + b,
 	}
-	bf.chars['O'] = []string{
+	chars['O'] = []string{
 		s + b + b + b + s,
 		b + s + s + s + b,
 		b + s + s + s + b,
 		b + s + s + s + b,
 		s + b + b + b + s,
 	}
-	bf.chars['P'] = []string{
+	chars['P'] = []string{
 		b + b + b + b + s,
 		b + s + s + s + b,
 		b + b + b + b + s,
 		b + s + s + s + s,
 		b + s + s + s + s,
 	}
-	bf.chars['Q'] = []string{
+	chars['Q'] = []string{
 		s + b + b + b + s,
 		b + s + s + s + b,
 		b + s + s + s + b,
 		b + s + s + b + s,
 		s + b + b + s + b,
 	}
-	bf.chars['R'] = []string{
+	chars['R'] = []string{
 		b + b + b + b + s,
 		b + s + s + s + b,
 		b + b + b + b + s,
 		b + s + s + b + s,
 		b + s + s + s + b,
 	}
-	bf.chars['S'] = []string{
+	chars['S'] = []string{
 		s + b + b + b + b,
 		b + s + s + s + s,
 		s + b + b + b + s,
 		s + s + s + s + b,
 		b + b + b + b + s,
 	}
-	bf.chars['T'] = []string{
+	chars['T'] = []string{
 		b + b + b + b + b,
 		s + s + b + s + s,
 		s + s + b + s + s,
 		s + s + b + s + s,
 		s + s + b + s + s,
 	}
-	bf.chars['U'] = []string{
+	chars['U'] = []string{
 		b + s + s + s + b,
 		b + s + s + s + b,
 		b + s + s + s + b,
 		b + s + s + s + b,
 		s + b + b + b + s,
 	}
-	bf.chars['V'] = []string{
+	chars['V'] = []string{
 		b + s + s + s + b,
 		b + s + s + s + b,
 		b + s + s + s + b,
 		s + b + s + b + s,
 		s + s + b + s + s,
 	}
-	bf.chars['W'] = []string{
+	chars['W'] = []string{
 		b + s + s + s + b,
 		b + s + s + s + b,
 		b + s + b + s + b,
 		b + b + s + b + b,
 		b + s + s + s + b,
 	}
-	bf.chars['X'] = []string{
+	chars['X'] = []string{
 		b + s + s + s + b,
 		s + b + s + b + s,
 		s + s + b + s + s,
 		s + b + s + b + s,
 		b + s + s + s + b,
 	}
-	bf.chars['Y'] = []string{
+	chars['Y'] = []string{
 		b + s + s + s + b,
 		s + b + s + b + s,
 		s + s + b + s + s,
 		s + s + b + s + s,
 		s + s + b + s + s,
 	}
-	bf.chars['Z'] = []string{
+	chars['Z'] = []string{
 		b + b + b + b + b,
 		s + s + s + b + s,
 		s + s + b + s + s,
@@ -326,70 +473,70 @@ func (bf *BlockFont) initChars() {
 	}
 
 	// Numbers
-	bf.chars['0'] = []string{
+	chars['0'] = []string{
 		s + b + b + b + s,
 		b + s + s + b + b,
 		b + s + b + s + b,
 		b + b + s + s + b,
 		s + b + b + b + s,
 	}
-	bf.chars['1'] = []string{
+	chars['1'] = []string{
 		s + s + b + s + s,
 		s + b + b + s + s,
 		s + s + b + s + s,
 		s + s + b + s + s,
 		b + b + b + b + b,
 	}
-	bf.chars['2'] = []string{
+	chars['2'] = []string{
 		s + b + b + b + s,
 		b + s + s + s + b,
 		s + s + b + b + s,
 		s + b + s + s + s,
 		b + b + b + b + b,
 	}
-	bf.chars['3'] = []string{
+	chars['3'] = []string{
 		b + b + b + b + s,
 		s + s + s + s + b,
 		s + b + b + b + s,
 		s + s + s + s + b,
 		b + b + b + b + s,
 	}
-	bf.chars['4'] = []string{
+	chars['4'] = []string{
 		b + s + s + s + b,
 		b + s + s + s + b,
 		b + b + b + b + b,
 		s + s + s + s + b,
 		s + s + s + s + b,
 	}
-	bf.chars['5'] = []string{
+	chars['5'] = []string{
 		b + b + b + b + b,
 		b + s + s + s + s,
 		b + b + b + b + s,
 		s + s + s + s + b,
 		b + b + b + b + s,
 	}
-	bf.chars['6'] = []string{
+	chars['6'] = []string{
 		s + b + b + b + s,
 		b + s + s + s + s,
 		b + b + b + b + s,
 		b + s + s + s + b,
 		s + b + b + b + s,
 	}
-	bf.chars['7'] = []string{
+	chars['7'] = []string{
 		b + b + b + b + b,
 		s + s + s + s + b,
 		s + s + s + b + s,
 		s + s + b + s + s,
 		s + s + b + s + s,
 	}
-	bf.chars['8'] = []string{
+	chars['8'] = []string{
 		s + b + b + b + s,
 		b + s + s + s + b,
 		s + b + b + b + s,
 		b + s + s + s + b,
 		s + b + b + b + s,
 	}
-	bf.chars['9'] = []string{
+	chars['9'] = []string{
 		s + b + b + b + s,
 		b + s + s + s + b,
 		s + b + b + b + b,
@@ -398,161 +545,161 @@ func (bf *BlockFont) initChars() {
 	}
 
 	// Symbols
-	bf.chars[' '] = []string{
-		s + s + s + s + s,
-		s + s + s + s + s,
-		s + s + s + s + s,
-		s + s + s + s + s,
-		s + s + s + s + s,
+	chars[' '] = []string{
+		s + s + s,
+		s + s + s,
+		s + s + s,
+		s + s + s,
+		s + s + s,
 	}
-	bf.chars['!'] = []string{
+	chars['!'] = []string{
 		s + s + b + s + s,
 		s + s + b + s + s,
 		s + s + b + s + s,
 		s + s + s + s + s,
 		s + s + b + s + s,
 	}
-	bf.chars['?'] = []string{
+	chars['?'] = []string{
 		s + b + b + b + s,
 		b + s + s + s + b,
 		s + s + s + b + s,
 		s + s + s + s + s,
 		s + s + b + s + s,
 	}
-	bf.chars['.'] = []string{
+	chars['.'] = []string{
 		s + s + s + s + s,
 		s + s + s + s + s,
 		s + s + s + s + s,
 		s + s + s + s + s,
 		s + s + b + s + s,
 	}
-	bf.chars[','] = []string{
+	chars[','] = []string{
 		s + s + s + s + s,
 		s + s + s + s + s,
 		s + s + s + s + s,
 		s + s + b + s + s,
 		s + b + s + s + s,
 	}
-	bf.chars[':'] = []string{
+	chars[':'] = []string{
 		s + s + s + s + s,
 		s + s + b + s + s,
 		s + s + s + s + s,
 		s + s + b + s + s,
 		s + s + s + s + s,
 	}
-	bf.chars['-'] = []string{
+	chars['-'] = []string{
 		s + s + s + s + s,
 		s + s + s + s + s,
 		b + b + b + b + b,
 		s + s + s + s + s,
 		s + s + s + s + s,
 	}
-	bf.chars['_'] = []string{
+	chars['_'] = []string{
 		s + s + s + s + s,
 		s + s + s + s + s,
 		s + s + s + s + s,
 		s + s + s + s + s,
 		b + b + b + b + b,
 	}
-	bf.chars['/'] = []string{
+	chars['/'] = []string{
 		s + s + s + s + b,
 		s + s + s + b + s,
 		s + s + b + s + s,
 		s + b + s + s + s,
 		b + s + s + s + s,
 	}
-	bf.chars['('] = []string{
+	chars['('] = []string{
 		s + s + b + s + s,
 		s + b + s + s + s,
 		s + b + s + s + s,
 		s + b + s + s + s,
 		s + s + b + s + s,
 	}
-	bf.chars[')'] = []string{
+	chars[')'] = []string{
 		s + s + b + s + s,
 		s + s + s + b + s,
 		s + s + s + b + s,
 		s + s + s + b + s,
 		s + s + b + s + s,
 	}
-	bf.chars['<'] = []string{
+	chars['<'] = []string{
 		s + s + s + b + s,
 		s + s + b + s + s,
 		s + b + s + s + s,
 		s + s + b + s + s,
 		s + s + s + b + s,
 	}
-	bf.chars['>'] = []string{
+	chars['>'] = []string{
 		s + b + s + s + s,
 		s + s + b + s + s,
 		s + s + s + b + s,
 		s + s + b + s + s,
 		s + b + s + s + s,
 	}
-	bf.chars['='] = []string{
+	chars['='] = []string{
 		s + s + s + s + s,
 		b + b + b + b + b,
 		s + s + s + s + s,
 		b + b + b + b + b,
 		s + s + s + s + s,
 	}
-	bf.chars['+'] = []string{
+	chars['+'] = []string{
 		s + s + s + s + s,
 		s + s + b + s + s,
 		s + b + b + b + s,
 		s + s + b + s + s,
 		s + s + s + s + s,
 	}
-	bf.chars['#'] = []string{
+	chars['#'] = []string{
 		s + b + s + b + s,
 		b + b + b + b + b,
 		s + b + s + b + s,
 		b + b + b + b + b,
 		s + b + s + b + s,
 	}
-	bf.chars['@'] = []string{
+	chars['@'] = []string{
 		s + b + b + b + s,
 		b + s + b + s + b,
 		b + s + b + b + b,
 		b + s + s + s + s,
 		s + b + b + b + b,
 	}
-	bf.chars['*'] = []string{
+	chars['*'] = []string{
 		s + s + s + s + s,
 		b + s + b + s + b,
 		s + s + b + s + s,
 		b + s + b + s + b,
 		s + s + s + s + s,
 	}
-	bf.chars['%'] = []string{
+	chars['%'] = []string{
 		b + b + s + s + b,
 		b + b + s + b + s,
 		s + s + b + s + s,
 		s + b + s + b + b,
 		b + s + s + b + b,
 	}
-	bf.chars['$'] = []string{
+	chars['$'] = []string{
 		s + b + b + b + b,
 		b + s + b + s + s,
 		s + b + b + b + s,
 		s + s + b + s + b,
 		b + b + b + b + s,
 	}
-	bf.chars['&'] = []string{
+	chars['&'] = []string{
 		s + b + b + s + s,
 		b + s + s + b + s,
 		s + b + b + s + b,
 		b + s + s + b + s,
 		s + b + b + s + b,
 	}
-	bf.chars['\''] = []string{
+	chars['\''] = []string{
 		s + s + b + s + s,
 		s + s + b + s + s,
 		s + s + s + s + s,
 		s + s + s + s + s,
 		s + s + s + s + s,
 	}
-	bf.chars['"'] = []string{
+	chars['"'] = []string{
 		s + b + s + b + s,
 		s + b + s + b + s,
 		s + s + s + s + s,
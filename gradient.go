@@ -0,0 +1,104 @@
+package gofig
+
+// GradientDirection is the axis along which a gradient changes
+type GradientDirection int
+
+const (
+	// GradientHorizontal changes color from left to right
+	GradientHorizontal GradientDirection = iota
+	// GradientVertical changes color from top to bottom
+	GradientVertical
+	// GradientDiagonal changes color from the top left to the bottom right
+	GradientDiagonal
+)
+
+// Gradient blends two or more colors across the rendered text
+type Gradient struct {
+	// Stops are the colors, evenly spaced from start to end
+	Stops []Color
+	// Direction is the axis the colors change along
+	Direction GradientDirection
+}
+
+// NewGradient creates a gradient through the given colors
+func NewGradient(direction GradientDirection, stops ...Color) Gradient {
+	return Gradient{Stops: stops, Direction: direction}
+}
+
+// SetGradient sets the gradient used instead of Config.Color
+func (bf *BlockFont) SetGradient(gradient Gradient) {
+	bf.config.Gradient = gradient
+}
+
+// at returns the gradient color for cell (x, y) of a width x height area
+func (g Gradient) at(x, y, width, height int) Color {
+	fraction := func(v, size int) float64 {
+		if size <= 1 {
+			return 0
+		}
+		return float64(v) / float64(size-1)
+	}
+
+	var t float64
+	switch g.Direction {
+	case GradientVertical:
+		t = fraction(y, height)
+	case GradientDiagonal:
+		t = (fraction(x, width) + fraction(y, height)) / 2
+	default:
+		t = fraction(x, width)
+	}
+	return g.color(t)
+}
+
+// color returns the gradient color at position t in [0, 1]
+func (g Gradient) color(t float64) Color {
+	switch len(g.Stops) {
+	case 0:
+		return NoColor
+	case 1:
+		return g.Stops[0]
+	}
+
+	pos := t * float64(len(g.Stops)-1)
+	i := min(int(pos), len(g.Stops)-2)
+	return mixColors(g.Stops[i], g.Stops[i+1], pos-float64(i))
+}
+
+// mixColors interpolates between two colors in RGB space
+func mixColors(from, to Color, t float64) Color {
+	r1, g1, b1 := from.RGB()
+	r2, g2, b2 := to.RGB()
+	lerp := func(a, b uint8) uint8 {
+		return uint8(float64(a) + (float64(b)-float64(a))*t + 0.5)
+	}
+	return RGB(lerp(r1, r2), lerp(g1, g2), lerp(b1, b2))
+}
+
+// blendColors returns the shared color of a group of cells, or the
+// average of their colors if they differ
+func blendColors(colors []Color) Color {
+	same := true
+	for _, c := range colors[1:] {
+		if c != colors[0] {
+			same = false
+			break
+		}
+	}
+	if same {
+		return colors[0]
+	}
+
+	var r, g, b, n int
+	for _, c := range colors {
+		if c == NoColor {
+			continue
+		}
+		cr, cg, cb := c.RGB()
+		r, g, b, n = r+int(cr), g+int(cg), b+int(cb), n+1
+	}
+	if n == 0 {
+		return NoColor
+	}
+	return RGB(uint8(r/n), uint8(g/n), uint8(b/n))
+}
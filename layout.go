@@ -0,0 +1,357 @@
+package gofig
+
+import "strings"
+
+// Layout controls how neighbouring glyphs are fitted together
+type Layout int
+
+const (
+	// LayoutDefault uses the layout preferred by the font
+	LayoutDefault Layout = iota
+	// LayoutFullWidth places glyphs side by side as designed
+	LayoutFullWidth
+	// LayoutKerning moves glyphs together until they touch
+	LayoutKerning
+	// LayoutSmushing overlaps glyphs by one column using the smush rules
+	LayoutSmushing
+)
+
+// Align positions lines of different width within multi-line text
+type Align int
+
+const (
+	// AlignLeft lines up lines on the left edge
+	AlignLeft Align = iota
+	// AlignCenter centers every line
+	AlignCenter
+	// AlignRight lines up lines on the right edge
+	AlignRight
+)
+
+// SmushRule is a set of FIGlet horizontal smushing rules.
+// An empty set means universal smushing: the later glyph wins.
+type SmushRule int
+
+const (
+	// SmushEqual merges two identical characters into one
+	SmushEqual SmushRule = 1 << iota
+	// SmushUnderscore lets '_' be replaced by |/\[]{}()<>
+	SmushUnderscore
+	// SmushHierarchy lets the higher class of |, /\, [], {}, (), <> win
+	SmushHierarchy
+	// SmushPair turns opposing brackets into '|'
+	SmushPair
+	// SmushBigX turns /\ into '|', \/ into 'Y' and >< into 'X'
+	SmushBigX
+	// SmushHardblank merges two hardblanks into one
+	SmushHardblank
+
+	// SmushAll enables every standard rule
+	SmushAll = SmushEqual | SmushUnderscore | SmushHierarchy | SmushPair | SmushBigX | SmushHardblank
+)
+
+// figletLayout converts the layout values of a FIGlet header
+func figletLayout(oldLayout, fullLayout int) (Layout, SmushRule) {
+	if fullLayout >= 0 {
+		rules := SmushRule(fullLayout) & SmushAll
+		switch {
+		case fullLayout&128 != 0:
+			return LayoutSmushing, rules
+		case fullLayout&64 != 0:
+			return LayoutKerning, rules
+		default:
+			return LayoutFullWidth, rules
+		}
+	}
+	switch {
+	case oldLayout < 0:
+		return LayoutFullWidth, 0
+	case oldLayout == 0:
+		return LayoutKerning, 0
+	default:
+		return LayoutSmushing, SmushRule(oldLayout) & SmushAll
+	}
+}
+
+// layoutMode returns the effective layout and smush rules
+func (bf *BlockFont) layoutMode() (Layout, SmushRule) {
+	info := bf.font.Info()
+	layout, rules := bf.config.Layout, bf.config.SmushRules
+	if layout == LayoutDefault {
+		layout = info.Layout
+	}
+	if layout == LayoutDefault {
+		layout = LayoutFullWidth
+	}
+	if rules == 0 {
+		rules = info.SmushRules
+	}
+	return layout, rules
+}
+
+// layoutLine returns the column at which every glyph of a line starts
+func (bf *BlockFont) layoutLine(glyphs []glyph) []int {
+	l := bf.newLineLayout()
+	offsets := make([]int, len(glyphs))
+	for i, g := range glyphs {
+		offsets[i] = l.add(g)
+	}
+	return offsets
+}
+
+// lineLayout places glyphs one at a time, so a line can be measured
+// without laying out all of it
+type lineLayout struct {
+	bf        *BlockFont
+	layout    Layout
+	rules     SmushRule
+	spacing   int
+	rows      [][]cell
+	prevWidth int
+	// floor is the first column the next glyph may use: the end of the
+	// last blank glyph, so fitted glyphs never slide into a word space
+	floor int
+}
+
+// newLineLayout starts laying out an empty line
+func (bf *BlockFont) newLineLayout() *lineLayout {
+	layout, rules := bf.layoutMode()
+	return &lineLayout{bf: bf, layout: layout, rules: rules, spacing: bf.font.Info().Spacing}
+}
+
+// add places g after the glyphs added so far and returns its column
+func (l *lineLayout) add(g glyph) int {
+	offset := 0
+	switch {
+	case l.rows == nil:
+	case l.layout == LayoutFullWidth || g.blank():
+		// Blank glyphs such as space would vanish if fitted
+		offset = len(l.rows[0]) + l.spacing
+	default:
+		amount := l.bf.smushAmount(l.rows, g, l.prevWidth, l.layout, l.rules)
+		if l.layout == LayoutKerning {
+			amount -= l.spacing
+		}
+		offset = max(len(l.rows[0])-amount, l.floor)
+	}
+	l.rows = l.bf.drawGlyph(l.rows, g, offset, l.rules)
+	l.prevWidth = g.width()
+	if g.blank() && l.layout != LayoutFullWidth {
+		l.floor = offset + g.width()
+		if l.layout == LayoutKerning {
+			l.floor += l.spacing
+		}
+	}
+	return offset
+}
+
+// width returns the width of the line so far in glyph columns
+func (l *lineLayout) width() int {
+	if len(l.rows) == 0 {
+		return 0
+	}
+	return len(l.rows[0])
+}
+
+// smushAmount returns how many columns g can overlap the end of rows
+func (bf *BlockFont) smushAmount(rows [][]cell, g glyph, prevWidth int, layout Layout, rules SmushRule) int {
+	width := g.width()
+	amount := width
+	for y, row := range g.rows {
+		line := rows[y]
+		// Last filled column of the line so far
+		lineEnd := len(line) - 1
+		for lineEnd > 0 && line[lineEnd].ch == ' ' {
+			lineEnd--
+		}
+		// First filled column of the glyph
+		charStart := 0
+		for charStart < len(row) && row[charStart] == ' ' {
+			charStart++
+		}
+
+		rowAmount := charStart + len(line) - 1 - lineEnd
+		left := ' '
+		if lineEnd >= 0 {
+			left = line[lineEnd].ch
+		}
+		if left == ' ' {
+			rowAmount++
+		} else if charStart < len(row) && layout == LayoutSmushing {
+			if _, ok := bf.smush(left, row[charStart], prevWidth, width, rules); ok {
+				rowAmount++
+			}
+		}
+		amount = min(amount, rowAmount)
+	}
+	return amount
+}
+
+// smush merges two overlapping characters following the FIGlet rules
+func (bf *BlockFont) smush(left, right rune, leftWidth, rightWidth int, rules SmushRule) (rune, bool) {
+	if left == ' ' {
+		return right, true
+	}
+	if right == ' ' {
+		return left, true
+	}
+	if leftWidth < 2 || rightWidth < 2 {
+		return 0, false
+	}
+
+	hardblank := bf.font.Info().Hardblank
+	if rules == 0 {
+		// Universal smushing
+		if right == hardblank {
+			return left, true
+		}
+		return right, true
+	}
+
+	if rules&SmushHardblank != 0 && left == hardblank && right == hardblank {
+		return left, true
+	}
+	if left == hardblank || right == hardblank {
+		return 0, false
+	}
+	if rules&SmushEqual != 0 && left == right {
+		return left, true
+	}
+	if rules&SmushUnderscore != 0 {
+		if left == '_' && strings.ContainsRune(`|/\[]{}()<>`, right) {
+			return right, true
+		}
+		if right == '_' && strings.ContainsRune(`|/\[]{}()<>`, left) {
+			return left, true
+		}
+	}
+	if rules&SmushHierarchy != 0 {
+		classes := []string{"|", `/\`, "[]", "{}", "()", "<>"}
+		for i, class := range classes {
+			higher := strings.Join(classes[i+1:], "")
+			if strings.ContainsRune(class, left) && strings.ContainsRune(higher, right) {
+				return right, true
+			}
+			if strings.ContainsRune(class, right) && strings.ContainsRune(higher, left) {
+				return left, true
+			}
+		}
+	}
+	if rules&SmushPair != 0 {
+		switch string([]rune{left, right}) {
+		case "[]", "][", "{}", "}{", "()", ")(":
+			return '|', true
+		}
+	}
+	if rules&SmushBigX != 0 {
+		switch string([]rune{left, right}) {
+		case `/\`:
+			return '|', true
+		case `\/`:
+			return 'Y', true
+		case "><":
+			return 'X', true
+		}
+	}
+	return 0, false
+}
+
+// drawGlyph draws g into rows at column x, smushing overlapping cells
+func (bf *BlockFont) drawGlyph(rows [][]cell, g glyph, x int, rules SmushRule) [][]cell {
+	if rows == nil {
+		rows = make([][]cell, len(g.rows))
+	}
+	for y, row := range g.rows {
+		for len(rows[y]) < x+len(row) {
+			rows[y] = append(rows[y], cell{ch: ' ', index: -1})
+		}
+		for dx, ch := range row {
+			target := &rows[y][x+dx]
+			if ch == ' ' {
+				// Empty cells still belong to the letter box
+				if target.index < 0 {
+					target.index = g.index
+				}
+				continue
+			}
+			if target.ch != ' ' {
+				if merged, ok := bf.smush(target.ch, ch, 2, 2, rules); ok {
+					ch = merged
+				}
+			}
+			*target = cell{ch: ch, index: g.index}
+		}
+	}
+	// Keep all rows the same width
+	width := 0
+	for _, row := range rows {
+		width = max(width, len(row))
+	}
+	for y := range rows {
+		for len(rows[y]) < width {
+			rows[y] = append(rows[y], cell{ch: ' ', index: -1})
+		}
+	}
+	return rows
+}
+
+// drawLine draws glyphs at the columns returned by layoutLine
+func (bf *BlockFont) drawLine(glyphs []glyph, offsets []int) [][]cell {
+	_, rules := bf.layoutMode()
+	rows := make([][]cell, bf.font.Height())
+	for i, g := range glyphs {
+		rows = bf.drawGlyph(rows, g, offsets[i], rules)
+	}
+	return rows
+}
+
+// stackLines places laid out lines under each other, aligned to the widest
+// one and separated by Config.LineSpacing empty rows
+func (bf *BlockFont) stackLines(lines [][][]cell) [][]cell {
+	width := 0
+	for _, rows := range lines {
+		if len(rows) > 0 {
+			width = max(width, len(rows[0]))
+		}
+	}
+
+	blankRow := func(n int) []cell {
+		row := make([]cell, n)
+		for i := range row {
+			row[i] = cell{ch: ' ', index: -1}
+		}
+		return row
+	}
+
+	var stacked [][]cell
+	for i, rows := range lines {
+		if i > 0 {
+			for s := 0; s < bf.config.LineSpacing; s++ {
+				stacked = append(stacked, blankRow(width))
+			}
+		}
+		for _, row := range rows {
+			extra := width - len(row)
+			left := 0
+			switch bf.config.Align {
+			case AlignCenter:
+				left = extra / 2
+			case AlignRight:
+				left = extra
+			}
+			line := append(blankRow(left), row...)
+			stacked = append(stacked, append(line, blankRow(extra-left)...))
+		}
+	}
+	return stacked
+}
+
+// SetAlign changes how lines of multi-line text are aligned
+func (bf *BlockFont) SetAlign(align Align) {
+	bf.config.Align = align
+}
+
+// SetLayout changes how glyphs are fitted together
+func (bf *BlockFont) SetLayout(layout Layout) {
+	bf.config.Layout = layout
+}
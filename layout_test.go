@@ -0,0 +1,167 @@
+package gofig
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSmush(t *testing.T) {
+	bf := New()
+	bf.SetFont(NewBitmapFont(FontInfo{Hardblank: '$'}, 1))
+
+	const none = rune(0)
+	tests := []struct {
+		left, right rune
+		rules       SmushRule
+		want        rune
+	}{
+		// Universal smushing: the later character wins over all but hardblanks
+		{'a', 'b', 0, 'b'},
+		{'|', '$', 0, '|'},
+		{'$', '|', 0, '|'},
+		{' ', 'x', SmushEqual, 'x'},
+		{'x', ' ', SmushEqual, 'x'},
+
+		{'|', '|', SmushEqual, '|'},
+		{'a', 'b', SmushEqual, none},
+		{'_', '|', SmushUnderscore, '|'},
+		{'/', '_', SmushUnderscore, '/'},
+		{'_', 'a', SmushUnderscore, none},
+		{'|', '/', SmushHierarchy, '/'},
+		{'}', '[', SmushHierarchy, '}'},
+		{'<', '(', SmushHierarchy, '<'},
+		{'/', '\\', SmushHierarchy, none},
+		{'[', ']', SmushPair, '|'},
+		{')', '(', SmushPair, '|'},
+		{'(', '}', SmushPair, none},
+		{'/', '\\', SmushBigX, '|'},
+		{'\\', '/', SmushBigX, 'Y'},
+		{'>', '<', SmushBigX, 'X'},
+		{'<', '>', SmushBigX, none},
+		{'$', '$', SmushHardblank, '$'},
+		{'$', '$', SmushEqual, none},
+		{'$', '|', SmushAll, none},
+	}
+	for _, tt := range tests {
+		got, ok := bf.smush(tt.left, tt.right, 2, 2, tt.rules)
+		if !ok {
+			got = none
+		}
+		if got != tt.want {
+			t.Errorf("smush(%q, %q, %d) = %q, want %q", tt.left, tt.right, tt.rules, got, tt.want)
+		}
+	}
+
+	// Glyphs one column wide are never smushed
+	if _, ok := bf.smush('|', '|', 1, 2, SmushEqual); ok {
+		t.Error("smush of a one column glyph succeeded")
+	}
+}
+
+func TestLayoutFIGlet(t *testing.T) {
+	font, err := ParseFIGletFont(strings.NewReader(testFIGletFont("flf2a$ 6 5 16 15 0 0 24463", nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		layout    Layout
+		universal bool
+		want      []string
+	}{
+		{
+			// The output of "figlet Hello" with the standard font
+			name:   "smushing",
+			layout: LayoutSmushing,
+			want: []string{
+				` _   _      _ _       `,
+				`| | | | ___| | | ___  `,
+				`| |_| |/ _ \ | |/ _ \ `,
+				`|  _  |  __/ | | (_) |`,
+				`|_| |_|\___|_|_|\___/ `,
+				`                      `,
+			},
+		},
+		{
+			// The output of "figlet -k Hello"
+			name:   "kerning",
+			layout: LayoutKerning,
+			want: []string{
+				` _   _        _  _        `,
+				`| | | |  ___ | || |  ___  `,
+				`| |_| | / _ \| || | / _ \ `,
+				`|  _  ||  __/| || || (_) |`,
+				`|_| |_| \___||_||_| \___/ `,
+				`                          `,
+			},
+		},
+		{
+			// Universal smushing lets the later glyph win where they overlap
+			name:      "universal",
+			layout:    LayoutSmushing,
+			universal: true,
+			want: []string{
+				` _   _      _ _       `,
+				`| | | | ___| | | ___  `,
+				`| |_| |/ _ | | |/ _ \ `,
+				`|  _  |  __| | | (_) |`,
+				`|_| |_|\___|_|_|\___/ `,
+				`                      `,
+			},
+		},
+	}
+	for _, tt := range tests {
+		f := *font.BitmapFont
+		if tt.universal {
+			f.info.SmushRules = 0
+		}
+		config := DefaultConfig()
+		config.PreserveCase = true
+		config.ColorProfile = ProfileNone
+		config.Layout = tt.layout
+		got := NewWithFont(&f, config).Render("Hello")
+		if want := strings.Join(tt.want, "\n"); got != want {
+			t.Errorf("%s:\n%s\nwant:\n%s", tt.name, got, want)
+		}
+	}
+}
+
+func TestLayoutWordSpace(t *testing.T) {
+	tests := []struct {
+		layout Layout
+		want   []string
+	}{
+		{
+			// The space keeps its width plus the font spacing
+			layout: LayoutKerning,
+			want: []string{
+				` ███      ████ `,
+				`█   █     █   █`,
+				`█████     ████ `,
+				`█   █     █   █`,
+				`█   █     ████ `,
+			},
+		},
+		{
+			// Letters may touch the space but never overlap it
+			layout: LayoutSmushing,
+			want: []string{
+				` ███     ████ `,
+				`█   █    █   █`,
+				`█████    ████ `,
+				`█   █    █   █`,
+				`█   █    ████ `,
+			},
+		},
+	}
+	for _, tt := range tests {
+		config := DefaultConfig()
+		config.ColorProfile = ProfileNone
+		config.Layout = tt.layout
+		got := NewWithConfig(config).Render("A B")
+		if want := strings.Join(tt.want, "\n"); got != want {
+			t.Errorf("layout %d:\n%s\nwant:\n%s", tt.layout, got, want)
+		}
+	}
+}
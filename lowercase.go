@@ -0,0 +1,192 @@
+package gofig
+
+// initLowercaseChars adds lowercase Latin letters to the built-in font.
+// Letters are four rows high with ascenders reaching the top row;
+// g, j, p, q and y sit one row higher to fit their descenders.
+func initLowercaseChars(chars map[rune][]string) {
+	b := "█"
+	s := " "
+
+	chars['a'] = []string{
+		s + s + s + s + s,
+		s + b + b + b + s,
+		b + s + s + b + s,
+		b + s + s + b + s,
+		s + b + b + b + s,
+	}
+	chars['b'] = []string{
+		b + s + s + s + s,
+		b + b + b + s + s,
+		b + s + s + b + s,
+		b + s + s + b + s,
+		b + b + b + s + s,
+	}
+	chars['c'] = []string{
+		s + s + s + s + s,
+		s + b + b + b + s,
+		b + s + s + s + s,
+		b + s + s + s + s,
+		s + b + b + b + s,
+	}
+	chars['d'] = []string{
+		s + s + s + b + s,
+		s + b + b + b + s,
+		b + s + s + b + s,
+		b + s + s + b + s,
+		s + b + b + b + s,
+	}
+	chars['e'] = []string{
+		s + s + s + s + s,
+		s + b + b + s + s,
+		b + b + b + b + s,
+		b + s + s + s + s,
+		s + b + b + b + s,
+	}
+	chars['f'] = []string{
+		s + s + b + b + s,
+		s + b + s + s + s,
+		b + b + b + b + s,
+		s + b + s + s + s,
+		s + b + s + s + s,
+	}
+	chars['g'] = []string{
+		s + s + s + s + s,
+		s + b + b + b + s,
+		b + s + s + b + s,
+		s + b + b + b + s,
+		b + b + b + s + s,
+	}
+	chars['h'] = []string{
+		b + s + s + s + s,
+		b + b + b + s + s,
+		b + s + s + b + s,
+		b + s + s + b + s,
+		b + s + s + b + s,
+	}
+	chars['i'] = []string{
+		b + s + s + s + s,
+		s + s + s + s + s,
+		b + s + s + s + s,
+		b + s + s + s + s,
+		b + s + s + s + s,
+	}
+	chars['j'] = []string{
+		s + b + s + s + s,
+		s + s + s + s + s,
+		s + b + s + s + s,
+		s + b + s + s + s,
+		b + s + s + s + s,
+	}
+	chars['k'] = []string{
+		b + s + s + s + s,
+		b + s + s + b + s,
+		b + b + b + s + s,
+		b + s + b + s + s,
+		b + s + s + b + s,
+	}
+	chars['l'] = []string{
+		b + s + s + s + s,
+		b + s + s + s + s,
+		b + s + s + s + s,
+		b + s + s + s + s,
+		s + b + s + s + s,
+	}
+	chars['m'] = []string{
+		s + s + s + s + s,
+		b + b + b + b + s,
+		b + s + b + s + b,
+		b + s + b + s + b,
+		b + s + b + s + b,
+	}
+	chars['n'] = []string{
+		s + s + s + s + s,
+		b + b + b + s + s,
+		b + s + s + b + s,
+		b + s + s + b + s,
+		b + s + s + b + s,
+	}
+	chars['o'] = []string{
+		s + s + s + s + s,
+		s + b + b + s + s,
+		b + s + s + b + s,
+		b + s + s + b + s,
+		s + b + b + s + s,
+	}
+	chars['p'] = []string{
+		s + s + s + s + s,
+		b + b + b + s + s,
+		b + s + s + b + s,
+		b + b + b + s + s,
+		b + s + s + s + s,
+	}
+	chars['q'] = []string{
+		s + s + s + s + s,
+		s + b + b + b + s,
+		b + s + s + b + s,
+		s + b + b + b + s,
+		s + s + s + b + s,
+	}
+	chars['r'] = []string{
+		s + s + s + s + s,
+		b + s + b + b + s,
+		b + b + s + s + s,
+		b + s + s + s + s,
+		b + s + s + s + s,
+	}
+	chars['s'] = []string{
+		s + s + s + s + s,
+		s + b + b + b + s,
+		b + b + s + s + s,
+		s + s + b + b + s,
+		b + b + b + s + s,
+	}
+	chars['t'] = []string{
+		s + b + s + s + s,
+		b + b + b + b + s,
+		s + b + s + s + s,
+		s + b + s + s + s,
+		s + s + b + b + s,
+	}
+	chars['u'] = []string{
+		s + s + s + s + s,
+		b + s + s + b + s,
+		b + s + s + b + s,
+		b + s + s + b + s,
+		s + b + b + b + s,
+	}
+	chars['v'] = []string{
+		s + s + s + s + s,
+		b + s + s + s + b,
+		b + s + s + s + b,
+		s + b + s + b + s,
+		s + s + b + s + s,
+	}
+	chars['w'] = []string{
+		s + s + s + s + s,
+		b + s + s + s + b,
+		b + s + b + s + b,
+		b + s + b + s + b,
+		s + b + s + b + s,
+	}
+	chars['x'] = []string{
+		s + s + s + s + s,
+		b + s + s + b + s,
+		s + b + b + s + s,
+		s + b + b + s + s,
+		b + s + s + b + s,
+	}
+	chars['y'] = []string{
+		s + s + s + s + s,
+		b + s + s + b + s,
+		b + s + s + b + s,
+		s + b + b + b + s,
+		b + b + b + s + s,
+	}
+	chars['z'] = []string{
+		s + s + s + s + s,
+		b + b + b + b + s,
+		s + s + b + s + s,
+		s + b + s + s + s,
+		b + b + b + b + s,
+	}
+}
@@ -0,0 +1,101 @@
+package gofig
+
+// Orientation is the direction text runs in
+type Orientation int
+
+const (
+	// OrientationHorizontal lays out letters left to right
+	OrientationHorizontal Orientation = iota
+	// OrientationVertical stacks letters top to bottom, aligned by Config.Align
+	OrientationVertical
+	// OrientationRotate90 turns horizontal text 90 degrees clockwise
+	OrientationRotate90
+	// OrientationRotate180 turns horizontal text upside down
+	OrientationRotate180
+	// OrientationRotate270 turns horizontal text 90 degrees counterclockwise
+	OrientationRotate270
+)
+
+// rotateCharsCW maps characters to their images turned 90 degrees clockwise
+var rotateCharsCW = map[rune]rune{
+	'|': '-', '-': '|', '/': '\\', '\\': '/',
+	'▀': '▐', '▐': '▄', '▄': '▌', '▌': '▀',
+}
+
+// rotateChars180 maps characters to their images turned upside down
+var rotateChars180 = map[rune]rune{
+	'▀': '▄', '▄': '▀', '▌': '▐', '▐': '▌', '^': 'v', 'v': '^',
+	'_': '‾', '‾': '_',
+}
+
+// rotate turns laid out rows according to Config.Orientation
+func (bf *BlockFont) rotate(rows [][]cell) [][]cell {
+	if len(rows) == 0 {
+		return rows
+	}
+	height, width := len(rows), len(rows[0])
+
+	var rotated [][]cell
+	var chars map[rune]rune
+	switch bf.config.Orientation {
+	case OrientationRotate90:
+		rotated = newCellGrid(width, height)
+		for y, row := range rows {
+			for x, c := range row {
+				rotated[x][height-1-y] = c
+			}
+		}
+		chars = rotateCharsCW
+	case OrientationRotate180:
+		rotated = newCellGrid(height, width)
+		for y, row := range rows {
+			for x, c := range row {
+				rotated[height-1-y][width-1-x] = c
+			}
+		}
+		chars = rotateChars180
+	case OrientationRotate270:
+		rotated = newCellGrid(width, height)
+		for y, row := range rows {
+			for x, c := range row {
+				rotated[width-1-x][y] = c
+			}
+		}
+		// Turning counterclockwise maps characters the opposite way
+		chars = make(map[rune]rune, len(rotateCharsCW))
+		for from, to := range rotateCharsCW {
+			chars[to] = from
+		}
+	default:
+		return rows
+	}
+
+	for _, row := range rotated {
+		for x := range row {
+			if r, ok := chars[row[x].ch]; ok {
+				row[x].ch = r
+			}
+		}
+	}
+	return rotated
+}
+
+// newCellGrid returns a grid of the given size
+func newCellGrid(height, width int) [][]cell {
+	grid := make([][]cell, height)
+	for y := range grid {
+		grid[y] = make([]cell, width)
+	}
+	return grid
+}
+
+// rotated reports whether lines run along the height of the output,
+// so wrapping to a width does not apply
+func (o Orientation) rotated() bool {
+	return o == OrientationVertical || o == OrientationRotate90 || o == OrientationRotate270
+}
+
+// SetOrientation changes the direction text runs in
+func (bf *BlockFont) SetOrientation(orientation Orientation) {
+	bf.config.Orientation = orientation
+}
@@ -0,0 +1,122 @@
+package gofig
+
+// FillStyle selects how the inside of glyphs is drawn
+type FillStyle int
+
+const (
+	// FillSolid draws every glyph cell
+	FillSolid FillStyle = iota
+	// FillOutline draws only the edge cells of glyphs; glyphs are scaled
+	// up to at least minFillScale so thin strokes have an inside
+	FillOutline
+	// FillOutlineBox traces glyph edges with box-drawing characters
+	FillOutlineBox
+	// FillHollow draws edge cells with Char and the inside with HollowChar,
+	// scaled up like FillOutline
+	FillHollow
+)
+
+// minFillScale is the smallest scale at which a stroke one cell thick has
+// an inside: one cell between two edges
+const minFillScale = 3
+
+// boxChars is indexed by a mask of neighbouring edge cells
+// (up = 1, right = 2, down = 4, left = 8)
+var boxChars = []rune{
+	'■', '│', '─', '└', '│', '│', '┌', '├',
+	'─', '┘', '─', '┴', '┐', '┤', '┬', '┼',
+}
+
+// applyFill replaces the inside of glyphs according to Config.Fill.
+// Edges are found on the scaled grid; a cell is an edge if it borders an
+// empty cell, so the outline is one cell thick at every scale.
+func (bf *BlockFont) applyFill(rows [][]cell) {
+	fill := bf.config.Fill
+	if fill == FillSolid {
+		return
+	}
+
+	glyphCell := func(x, y int) bool {
+		if y < 0 || y >= len(rows) || x < 0 || x >= len(rows[y]) {
+			return false
+		}
+		c := rows[y][x]
+		return c.layer == 0 && bf.filled(c)
+	}
+	edges := make([][]bool, len(rows))
+	for y, row := range rows {
+		edges[y] = make([]bool, len(row))
+		for x := range row {
+			if !glyphCell(x, y) {
+				continue
+			}
+			// A cell is an edge if any of its eight neighbours is empty
+			for dy := -1; dy <= 1 && !edges[y][x]; dy++ {
+				for dx := -1; dx <= 1; dx++ {
+					if !glyphCell(x+dx, y+dy) {
+						edges[y][x] = true
+						break
+					}
+				}
+			}
+		}
+	}
+	isEdge := func(x, y int) bool {
+		return y >= 0 && y < len(edges) && x >= 0 && x < len(edges[y]) && edges[y][x]
+	}
+
+	for y, row := range rows {
+		for x := range row {
+			c := &row[x]
+			switch {
+			case !glyphCell(x, y):
+			case !edges[y][x] && fill == FillHollow:
+				c.interior = true
+			case !edges[y][x]:
+				c.ch = ' '
+			case fill == FillOutlineBox:
+				// Edge cells are joined only along a border they share:
+				// an empty cell on the same side of both. Thick strokes
+				// then trace their outline instead of a ladder.
+				mask := 0
+				for bit, d := range [][2]int{{0, -1}, {1, 0}, {0, 1}, {-1, 0}} {
+					nx, ny := x+d[0], y+d[1]
+					if !isEdge(nx, ny) {
+						continue
+					}
+					// Sides of the pair, across the direction joined
+					sx, sy := d[1], d[0]
+					if !glyphCell(x+sx, y+sy) || !glyphCell(nx+sx, ny+sy) ||
+						!glyphCell(x-sx, y-sy) || !glyphCell(nx-sx, ny-sy) {
+						mask |= 1 << bit
+					}
+				}
+				c.ch = boxChars[mask]
+			}
+		}
+	}
+}
+
+// fillScale returns the factor both scales are multiplied by so that
+// outline and hollow fills have room at the default scale. The ratio of
+// Config.ScaleX to Config.ScaleY is kept.
+func (bf *BlockFont) fillScale() int {
+	if bf.config.Fill != FillOutline && bf.config.Fill != FillHollow {
+		return 1
+	}
+	scale := min(bf.config.ScaleX, bf.config.ScaleY)
+	return (minFillScale + scale - 1) / scale
+}
+
+// hollowChar returns the character the inside of hollow glyphs is drawn with
+func (bf *BlockFont) hollowChar() string {
+	if bf.config.HollowChar == "" {
+		return "░"
+	}
+	return bf.config.HollowChar
+}
+
+// SetFill changes how the inside of glyphs is drawn
+func (bf *BlockFont) SetFill(fill FillStyle) {
+	bf.config.Fill = fill
+}
@@ -0,0 +1,16 @@
+package gofig
+
+import "testing"
+
+func TestFillDefaultScale(t *testing.T) {
+	config := DefaultConfig()
+	config.ColorProfile = ProfileNone
+	solid := NewWithConfig(config).Render("HI")
+
+	for _, fill := range []FillStyle{FillOutline, FillOutlineBox, FillHollow} {
+		config.Fill = fill
+		if got := NewWithConfig(config).Render("HI"); got == solid {
+			t.Errorf("fill %d at scale 1 looks solid:\n%s", fill, got)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package gofig
+
+// RenderMode selects how glyph cells map to terminal characters
+type RenderMode int
+
+const (
+	// ModeBlock draws every glyph cell as one terminal character
+	ModeBlock RenderMode = iota
+	// ModeHalfBlock packs two glyph rows into one line using ▀ ▄ █
+	ModeHalfBlock
+	// ModeQuadrant packs 2x2 glyph cells into one quadrant block character
+	ModeQuadrant
+	// ModeBraille packs 2x4 glyph cells into one braille character
+	ModeBraille
+)
+
+// halfBlockChars is indexed by a mask of filled halves (top = 1, bottom = 2)
+var halfBlockChars = []string{"", "▀", "▄", "█"}
+
+// quadrantChars is indexed by a mask of filled quadrants
+// (top left = 1, top right = 2, bottom left = 4, bottom right = 8)
+var quadrantChars = []string{
+	"", "▘", "▝", "▀", "▖", "▌", "▞", "▛",
+	"▗", "▚", "▐", "▜", "▄", "▙", "▟", "█",
+}
+
+// brailleDots maps a position in the 2x4 dot grid to its bit in U+2800..U+28FF
+var brailleDots = [4][2]rune{
+	{0x01, 0x08},
+	{0x02, 0x10},
+	{0x04, 0x20},
+	{0x40, 0x80},
+}
+
+// SetMode changes how glyph cells map to terminal characters
+func (bf *BlockFont) SetMode(mode RenderMode) {
+	bf.config.Mode = mode
+}
+
+// cellSize returns how many glyph cells one terminal character holds
+func (mode RenderMode) cellSize() (width, height int) {
+	switch mode {
+	case ModeHalfBlock:
+		return 1, 2
+	case ModeQuadrant:
+		return 2, 2
+	case ModeBraille:
+		return 2, 4
+	default:
+		return 1, 1
+	}
+}
+
+// packLines packs blocks of cells into one character each according to
+// the render mode
+func (bf *BlockFont) packLines(rows [][]cell) [][]termCell {
+	cw, ch := bf.config.Mode.cellSize()
+	width := 0
+	if len(rows) > 0 {
+		width = len(rows[0])
+	}
+
+	// Cells outside rows are never drawn and look like empty cells
+	blank := Style{Foreground: bf.config.SpaceColor, Background: bf.config.SpaceBackground}
+
+	lines := make([][]termCell, 0, (len(rows)+ch-1)/ch)
+	for y := 0; y < len(rows); y += ch {
+		line := make([]termCell, 0, (width+cw-1)/cw)
+		for x := 0; x < width; x += cw {
+			mask := 0
+			var styles []Style
+			var empty *Style
+			for dy := 0; dy < ch; dy++ {
+				for dx := 0; dx < cw; dx++ {
+					inside := y+dy < len(rows) && x+dx < width
+					c := cell{ch: ' ', index: -1, style: blank}
+					if inside {
+						c = rows[y+dy][x+dx]
+					}
+					if inside && bf.drawn(c) {
+						mask |= 1 << (dy*cw + dx)
+						styles = append(styles, c.style)
+					} else if empty == nil {
+						empty = &c.style
+					}
+				}
+			}
+			packed := bf.packedCell(mask, styles)
+			if empty != nil {
+				// Empty parts of the character show the empty cell background
+				if mask == 0 {
+					packed.style = *empty
+				} else {
+					packed.style.Background = empty.Background
+				}
+			}
+			line = append(line, packed)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// packedCell returns the character for a mask of filled cells, styled
+// after those cells
+func (bf *BlockFont) packedCell(mask int, styles []Style) termCell {
+	if mask == 0 {
+		return termCell{text: bf.config.Space}
+	}
+
+	style := styles[0]
+	colors := make([]Color, len(styles))
+	for i, s := range styles {
+		colors[i] = s.Foreground
+		style.Bold = style.Bold || s.Bold
+		style.Underline = style.Underline || s.Underline
+	}
+
+	switch bf.config.Mode {
+	case ModeHalfBlock:
+		// Two differently colored halves: one half as text, the other as
+		// background. The default color cannot be a background, so an
+		// uncolored bottom half is drawn as text instead.
+		if mask == 3 && colors[0] != colors[1] {
+			if colors[1] == NoColor {
+				style.Foreground, style.Background = NoColor, colors[0]
+				return termCell{text: "▄", style: style}
+			}
+			style.Background = colors[1]
+			return termCell{text: "▀", style: style}
+		}
+		return termCell{text: halfBlockChars[mask], style: style}
+	case ModeQuadrant:
+		style.Foreground = blendColors(colors)
+		return termCell{text: quadrantChars[mask], style: style}
+	case ModeBraille:
+		r := rune(0x2800)
+		for y := range brailleDots {
+			for x := range brailleDots[y] {
+				if mask&(1<<(y*2+x)) != 0 {
+					r |= brailleDots[y][x]
+				}
+			}
+		}
+		style.Foreground = blendColors(colors)
+		return termCell{text: string(r), style: style}
+	}
+	return termCell{text: bf.config.Char, style: style}
+}
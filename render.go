@@ -0,0 +1,290 @@
+package gofig
+
+import "strings"
+
+// cell is one position of laid out text
+type cell struct {
+	// ch is the glyph character; space is empty
+	ch rune
+	// index is the position of the source letter, -1 if none
+	index int
+	// layer is the shadow layer the cell belongs to, 0 for the glyph itself
+	layer int
+	// interior marks the inside of hollow glyphs
+	interior bool
+	// style is the look the cell is drawn with
+	style Style
+}
+
+// glyph is a letter pattern ready for layout
+type glyph struct {
+	rows  [][]rune
+	index int
+}
+
+// newGlyph converts pattern rows into a glyph for the letter at index
+func newGlyph(pattern []string, index int) glyph {
+	rows := make([][]rune, len(pattern))
+	for i, line := range pattern {
+		rows[i] = []rune(line)
+	}
+	return glyph{rows: rows, index: index}
+}
+
+// width returns the number of columns in the glyph
+func (g glyph) width() int {
+	if len(g.rows) == 0 {
+		return 0
+	}
+	return len(g.rows[0])
+}
+
+// blank reports whether the glyph has no visible cells
+func (g glyph) blank() bool {
+	for _, row := range g.rows {
+		for _, ch := range row {
+			if ch != ' ' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// patternFunc returns the pattern drawn for the letter at index.
+// Animation uses it to hide or replace letters in a frame.
+type patternFunc func(index int, ch rune) []string
+
+// renderOptions customize a single render call
+type renderOptions struct {
+	// pattern replaces the glyphs that are drawn
+	pattern patternFunc
+	// style styles individual letters
+	style StyleFunc
+}
+
+// render lays out text and converts it to a string. Lines are separated by
+// '\n' and wrapped to Config.MaxWidth; letter indices count runes of the
+// whole text, newlines included. If a pattern is set it replaces the glyphs
+// that are drawn, while the layout still follows the real glyphs so letters
+// never shift between animation frames.
+func (bf *BlockFont) render(text string, opts renderOptions) string {
+	runes := []rune(text)
+
+	var lines [][][]cell
+	for _, line := range bf.splitLines(runes) {
+		lines = append(lines, bf.renderLine(line, opts.pattern))
+	}
+	rows := bf.addShadow(bf.rotate(bf.stackLines(lines)))
+
+	return bf.output(rows, runes, opts)
+}
+
+// renderLine lays out one line of text. A hyphen added by wrapping does not
+// belong to any letter and has index -1.
+func (bf *BlockFont) renderLine(line textLine, pattern patternFunc) [][]cell {
+	runes, indices := line.runes, make([]int, len(line.runes))
+	for i := range runes {
+		indices[i] = line.first + i
+	}
+	if line.hyphen {
+		runes = append(runes[:len(runes):len(runes)], '-')
+		indices = append(indices, -1)
+	}
+
+	glyphs := make([]glyph, len(runes))
+	for i, ch := range runes {
+		glyphs[i] = newGlyph(bf.pattern(ch), indices[i])
+	}
+	offsets := bf.layoutLine(glyphs)
+
+	if pattern != nil {
+		for i, ch := range runes {
+			glyphs[i] = newGlyph(pattern(indices[i], ch), indices[i])
+		}
+	}
+	return bf.drawLine(glyphs, offsets)
+}
+
+// output scales laid out rows and converts them to a string
+func (bf *BlockFont) output(rows [][]cell, runes []rune, opts renderOptions) string {
+	_, scaleY := bf.scaleFactors()
+	scaled := make([][]cell, 0, len(rows)*scaleY)
+	for _, row := range rows {
+		// Scale the line horizontally
+		scaledLine := bf.scaleLine(row)
+		// Repeat for vertical scaling
+		for s := 0; s < scaleY; s++ {
+			scaled = append(scaled, append([]cell(nil), scaledLine...))
+		}
+	}
+	bf.applyFill(scaled)
+	bf.paint(scaled, runes, opts.style)
+
+	var screen [][]termCell
+	if bf.config.Mode == ModeBlock {
+		screen = make([][]termCell, len(scaled))
+		for i, row := range scaled {
+			screen[i] = bf.blockLine(row)
+		}
+	} else {
+		screen = bf.packLines(scaled)
+	}
+
+	return bf.serialize(bf.place(screen))
+}
+
+// paint sets the style of every cell: drawn cells take the gradient or
+// Config.Color on Config.Background, empty cells take Config.SpaceColor on
+// Config.SpaceBackground, then letter styles are applied on top
+func (bf *BlockFont) paint(rows [][]cell, runes []rune, style StyleFunc) {
+	height := len(rows)
+	width := 0
+	if height > 0 {
+		width = len(rows[0])
+	}
+	gradient := len(bf.config.Gradient.Stops) > 0
+
+	for y, row := range rows {
+		for x := range row {
+			c := &row[x]
+			drawn := bf.drawn(*c)
+			if c.layer > 0 && drawn {
+				c.style = Style{
+					Foreground: bf.config.Shadow.layerColor(c.layer),
+					Background: bf.config.SpaceBackground,
+				}
+				continue
+			}
+			if drawn {
+				c.style = Style{Foreground: bf.config.Color, Background: bf.config.Background}
+				if gradient {
+					c.style.Foreground = bf.config.Gradient.at(x, y, width, height)
+				}
+			} else {
+				c.style = Style{Foreground: bf.config.SpaceColor, Background: bf.config.SpaceBackground}
+			}
+			if style == nil || c.index < 0 || c.index >= len(runes) {
+				continue
+			}
+			letter := style(c.index, runes[c.index])
+			if !drawn {
+				// Empty cells of a letter only show its background
+				letter = Style{Background: letter.Background}
+			}
+			c.style = c.style.merge(letter)
+		}
+		if style != nil {
+			bf.fillGaps(row)
+		}
+	}
+}
+
+// fillGaps extends letter backgrounds over empty cells between letters that
+// share the same background, so styled words look like one block
+func (bf *BlockFont) fillGaps(row []cell) {
+	for x := 0; x < len(row); x++ {
+		if row[x].index >= 0 || x == 0 {
+			continue
+		}
+		bg := row[x-1].style.Background
+		end := x
+		for end < len(row) && row[end].index < 0 {
+			end++
+		}
+		if bg != NoColor && bg != bf.config.SpaceBackground && end < len(row) && row[end].style.Background == bg {
+			for i := x; i < end; i++ {
+				row[i].style.Background = bg
+			}
+		}
+		x = end
+	}
+}
+
+// filled reports whether a cell is part of a glyph (hardblanks are not)
+func (bf *BlockFont) filled(c cell) bool {
+	return c.ch != ' ' && c.ch != bf.font.Info().Hardblank
+}
+
+// drawn reports whether a cell is drawn with a block character: filled
+// cells normally, empty ones in reverse video
+func (bf *BlockFont) drawn(c cell) bool {
+	return bf.filled(c) != bf.config.Reverse
+}
+
+// blockLine converts a row to text using the custom block and space chars.
+// Characters other than █ and space (as used by FIGlet fonts) are kept as is.
+func (bf *BlockFont) blockLine(row []cell) []termCell {
+	line := make([]termCell, len(row))
+	for i, c := range row {
+		switch {
+		case !bf.drawn(c):
+			line[i] = termCell{text: bf.config.Space, style: c.style}
+		case c.interior && !bf.config.Reverse:
+			line[i] = termCell{text: bf.hollowChar(), style: c.style}
+		case c.layer > 0 && !bf.config.Reverse:
+			line[i] = termCell{text: bf.shadowChar(), style: c.style}
+		case c.ch == '█' || bf.config.Reverse:
+			line[i] = termCell{text: bf.config.Char, style: c.style}
+		default:
+			line[i] = termCell{text: string(c.ch), style: c.style}
+		}
+	}
+	return line
+}
+
+// shadowChar returns the character shadow cells are drawn with
+func (bf *BlockFont) shadowChar() string {
+	if bf.config.Shadow.Char == "" {
+		return "░"
+	}
+	return bf.config.Shadow.Char
+}
+
+// termCell is one character of output with its style
+type termCell struct {
+	text  string
+	style Style
+}
+
+// serialize joins output cells into lines, switching styles only where
+// they change and resetting them at the end of every line
+func (bf *BlockFont) serialize(screen [][]termCell) string {
+	profile := bf.config.ColorProfile
+	if profile == ProfileAuto {
+		profile = DetectColorProfile()
+	}
+
+	lines := make([]string, len(screen))
+	for y, row := range screen {
+		var line strings.Builder
+		if profile == ProfileNone {
+			for _, c := range row {
+				line.WriteString(c.text)
+			}
+			lines[y] = line.String()
+			continue
+		}
+
+		var current Style
+		// active is set while a sequence is in effect and needs a reset
+		active := false
+		for _, c := range row {
+			if c.style != current {
+				if active {
+					line.WriteString(ColorReset)
+				}
+				seq := c.style.sequence(profile)
+				line.WriteString(seq)
+				active = seq != ""
+				current = c.style
+			}
+			line.WriteString(c.text)
+		}
+		if active {
+			line.WriteString(ColorReset)
+		}
+		lines[y] = line.String()
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,102 @@
+package gofig
+
+// Shadow is a copy of the glyphs drawn behind them. With Depth above one
+// the copies are repeated at every step of the offset, making a 3D extrusion.
+type Shadow struct {
+	// X and Y offset the shadow from the glyphs in glyph cells
+	// (positive values go right and down)
+	X, Y int
+	// Depth is the number of layers (default: 1, a drop shadow)
+	Depth int
+	// Char draws the shadow cells (default: ░)
+	Char string
+	// Color is the color of the layer nearest to the glyphs
+	Color Color
+	// EndColor, if set, blends the layers towards this color at the back
+	EndColor Color
+}
+
+// IsZero reports whether the shadow is disabled
+func (s Shadow) IsZero() bool {
+	return s.X == 0 && s.Y == 0
+}
+
+// depth returns the number of shadow layers
+func (s Shadow) depth() int {
+	return max(s.Depth, 1)
+}
+
+// layerColor returns the color of shadow layer n (1 = nearest)
+func (s Shadow) layerColor(n int) Color {
+	depth := s.depth()
+	if s.EndColor == NoColor || depth < 2 {
+		return s.Color
+	}
+	return mixColors(s.Color, s.EndColor, float64(n-1)/float64(depth-1))
+}
+
+// extent returns the number of columns and rows the shadow adds
+func (s Shadow) extent() (width, height int) {
+	if s.IsZero() {
+		return 0, 0
+	}
+	return absInt(s.X) * s.depth(), absInt(s.Y) * s.depth()
+}
+
+// addShadow draws the shadow layers behind the glyphs in rows. The grid
+// grows by the shadow extent, so text keeps its position in every frame.
+func (bf *BlockFont) addShadow(rows [][]cell) [][]cell {
+	shadow := bf.config.Shadow
+	if shadow.IsZero() || len(rows) == 0 {
+		return rows
+	}
+	extWidth, extHeight := shadow.extent()
+	height, width := len(rows), len(rows[0])
+
+	// Negative offsets move the glyphs away from the top left corner
+	originX, originY := 0, 0
+	if shadow.X < 0 {
+		originX = extWidth
+	}
+	if shadow.Y < 0 {
+		originY = extHeight
+	}
+
+	result := make([][]cell, height+extHeight)
+	for y := range result {
+		result[y] = make([]cell, width+extWidth)
+		for x := range result[y] {
+			result[y][x] = cell{ch: ' ', index: -1}
+		}
+	}
+
+	// Layers are drawn from the back so nearer ones cover farther ones
+	for layer := shadow.depth(); layer >= 1; layer-- {
+		for y, row := range rows {
+			for x, c := range row {
+				if !bf.filled(c) {
+					continue
+				}
+				tx, ty := originX+x+layer*shadow.X, originY+y+layer*shadow.Y
+				result[ty][tx] = cell{ch: '█', index: c.index, layer: layer}
+			}
+		}
+	}
+	for y, row := range rows {
+		for x, c := range row {
+			target := &result[originY+y][originX+x]
+			switch {
+			case c.ch != ' ':
+				*target = c
+			case target.index < 0:
+				target.index = c.index
+			}
+		}
+	}
+	return result
+}
+
+// SetShadow draws a drop shadow or extrusion behind the glyphs
+func (bf *BlockFont) SetShadow(shadow Shadow) {
+	bf.config.Shadow = shadow
+}
@@ -0,0 +1,105 @@
+package gofig
+
+import "strings"
+
+// Style is the look of a letter: its colors and text attributes
+type Style struct {
+	// Foreground is the letter color (NoColor keeps Config.Color)
+	Foreground Color
+	// Background is the color behind the letter
+	Background Color
+	// Bold draws the letter with bold/bright attribute
+	Bold bool
+	// Underline underlines the letter cells
+	Underline bool
+}
+
+// StyleFunc returns the style of the letter at index in the rendered text
+type StyleFunc func(index int, r rune) Style
+
+// Span styles the letters from Start up to (not including) End.
+// Positions count runes, not bytes.
+type Span struct {
+	Start, End int
+	Style      Style
+}
+
+// Segment is a piece of text with its own style
+type Segment struct {
+	Text  string
+	Style Style
+}
+
+// IsZero reports whether the style changes nothing
+func (s Style) IsZero() bool {
+	return s == Style{}
+}
+
+// merge returns s with the fields set in over replacing its own
+func (s Style) merge(over Style) Style {
+	if over.Foreground != NoColor {
+		s.Foreground = over.Foreground
+	}
+	if over.Background != NoColor {
+		s.Background = over.Background
+	}
+	s.Bold = s.Bold || over.Bold
+	s.Underline = s.Underline || over.Underline
+	return s
+}
+
+// sequence returns the escape sequence that switches to the style
+func (s Style) sequence(profile ColorProfile) string {
+	if profile == ProfileNone {
+		return ""
+	}
+	var seq strings.Builder
+	if s.Bold {
+		seq.WriteString("\033[1m")
+	}
+	if s.Underline {
+		seq.WriteString("\033[4m")
+	}
+	seq.WriteString(s.Foreground.Foreground(profile))
+	seq.WriteString(s.Background.Background(profile))
+	return seq.String()
+}
+
+// RenderFunc renders text, styling every letter with the style returned
+// by style for its index
+func (bf *BlockFont) RenderFunc(text string, style StyleFunc) string {
+	return bf.render(text, renderOptions{style: style})
+}
+
+// RenderSpans renders text with the given ranges of letters styled.
+// Later spans take precedence over earlier ones where they overlap.
+func (bf *BlockFont) RenderSpans(text string, spans ...Span) string {
+	return bf.RenderFunc(text, spanStyle(spans))
+}
+
+// RenderSegments renders pieces of text with their own styles as one banner
+func (bf *BlockFont) RenderSegments(segments ...Segment) string {
+	var text strings.Builder
+	spans := make([]Span, 0, len(segments))
+	start := 0
+	for _, seg := range segments {
+		text.WriteString(seg.Text)
+		end := start + len([]rune(seg.Text))
+		spans = append(spans, Span{Start: start, End: end, Style: seg.Style})
+		start = end
+	}
+	return bf.RenderSpans(text.String(), spans...)
+}
+
+// spanStyle converts spans into a StyleFunc
+func spanStyle(spans []Span) StyleFunc {
+	return func(index int, r rune) Style {
+		var style Style
+		for _, span := range spans {
+			if index >= span.Start && index < span.End {
+				style = style.merge(span.Style)
+			}
+		}
+		return style
+	}
+}
@@ -0,0 +1,29 @@
+package gofig
+
+import (
+	"os"
+	"strconv"
+)
+
+// Default terminal size used when it cannot be detected
+const (
+	defaultTerminalWidth  = 80
+	defaultTerminalHeight = 24
+)
+
+// TerminalSize returns the size of the terminal in columns and rows.
+// It asks the terminal on stdout, then falls back to the COLUMNS and LINES
+// environment variables and finally to 80x24.
+func TerminalSize() (width, height int) {
+	if w, h, ok := ioctlTerminalSize(); ok {
+		return w, h
+	}
+	width, height = defaultTerminalWidth, defaultTerminalHeight
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 0 {
+		width = n
+	}
+	if n, err := strconv.Atoi(os.Getenv("LINES")); err == nil && n > 0 {
+		height = n
+	}
+	return width, height
+}
@@ -0,0 +1,8 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd || dragonfly)
+
+package gofig
+
+// ioctlTerminalSize is not supported on this platform
+func ioctlTerminalSize() (width, height int, ok bool) {
+	return 0, 0, false
+}
@@ -0,0 +1,170 @@
+package gofig
+
+// Transform is a set of changes applied to every glyph before layout
+type Transform int
+
+const (
+	// TransformItalic slants glyphs to the right, one column every two rows
+	TransformItalic Transform = 1 << iota
+	// TransformBold thickens glyphs by one column
+	TransformBold
+	// TransformCondensed removes a column from the widest blank or solid run
+	TransformCondensed
+	// TransformExpanded repeats the middle column of every glyph
+	TransformExpanded
+	// TransformMirror mirrors glyphs left to right
+	TransformMirror
+	// TransformFlip flips glyphs upside down
+	TransformFlip
+)
+
+// mirrorChars maps characters to their left-to-right mirror images
+var mirrorChars = map[rune]rune{
+	'(': ')', ')': '(', '[': ']', ']': '[', '{': '}', '}': '{',
+	'<': '>', '>': '<', '/': '\\', '\\': '/', '▌': '▐', '▐': '▌',
+}
+
+// flipChars maps characters to their upside down images
+var flipChars = map[rune]rune{
+	'/': '\\', '\\': '/', '▀': '▄', '▄': '▀', '^': 'v', 'v': '^',
+}
+
+// transform applies Config.Transform to glyph rows
+func (bf *BlockFont) transform(rows []string) []string {
+	t := bf.config.Transform
+	if t == 0 || len(rows) == 0 {
+		return rows
+	}
+	grid := make([][]rune, len(rows))
+	for i, row := range rows {
+		grid[i] = []rune(row)
+	}
+
+	if t&TransformCondensed != 0 {
+		grid = condense(grid)
+	}
+	if t&TransformExpanded != 0 {
+		grid = expand(grid)
+	}
+	if t&TransformBold != 0 {
+		grid = embolden(grid)
+	}
+	if t&TransformItalic != 0 {
+		grid = slant(grid)
+	}
+	if t&TransformMirror != 0 {
+		for _, row := range grid {
+			for i, j := 0, len(row)-1; i < j; i, j = i+1, j-1 {
+				row[i], row[j] = row[j], row[i]
+			}
+			replaceRunes(row, mirrorChars)
+		}
+	}
+	if t&TransformFlip != 0 {
+		for i, j := 0, len(grid)-1; i < j; i, j = i+1, j-1 {
+			grid[i], grid[j] = grid[j], grid[i]
+		}
+		for _, row := range grid {
+			replaceRunes(row, flipChars)
+		}
+	}
+
+	result := make([]string, len(grid))
+	for i, row := range grid {
+		result[i] = string(row)
+	}
+	return result
+}
+
+// column returns column x of a glyph as a string
+func column(grid [][]rune, x int) string {
+	col := make([]rune, len(grid))
+	for y, row := range grid {
+		col[y] = row[x]
+	}
+	return string(col)
+}
+
+// condense removes one column from the longest run of identical columns,
+// the one nearest the middle if several are equally long
+func condense(grid [][]rune) [][]rune {
+	width := len(grid[0])
+	best, bestLen := -1, 1
+	for x := 0; x < width; {
+		end := x + 1
+		for end < width && column(grid, end) == column(grid, x) {
+			end++
+		}
+		runLen := end - x
+		if runLen > bestLen || runLen == bestLen && best >= 0 &&
+			absInt(2*x+runLen-width) < absInt(2*best+bestLen-width) {
+			best, bestLen = x, runLen
+		}
+		x = end
+	}
+	if best < 0 {
+		return grid
+	}
+	for y, row := range grid {
+		grid[y] = append(row[:best:best], row[best+1:]...)
+	}
+	return grid
+}
+
+// expand repeats the middle column
+func expand(grid [][]rune) [][]rune {
+	mid := len(grid[0]) / 2
+	for y, row := range grid {
+		if mid < len(row) {
+			grid[y] = append(row[:mid+1:mid+1], row[mid:]...)
+		}
+	}
+	return grid
+}
+
+// embolden adds a copy of every drawn cell one column to its right
+func embolden(grid [][]rune) [][]rune {
+	for y, row := range grid {
+		bold := append(append([]rune(nil), row...), ' ')
+		for x, ch := range row {
+			if ch != ' ' && bold[x+1] == ' ' {
+				bold[x+1] = ch
+			}
+		}
+		grid[y] = bold
+	}
+	return grid
+}
+
+// slant shifts rows right by one column every two rows from the bottom
+func slant(grid [][]rune) [][]rune {
+	height := len(grid)
+	maxShift := (height - 1) / 2
+	for y, row := range grid {
+		shift := (height - 1 - y) / 2
+		slanted := make([]rune, 0, len(row)+maxShift)
+		for i := 0; i < shift; i++ {
+			slanted = append(slanted, ' ')
+		}
+		slanted = append(slanted, row...)
+		for len(slanted) < len(row)+maxShift {
+			slanted = append(slanted, ' ')
+		}
+		grid[y] = slanted
+	}
+	return grid
+}
+
+// replaceRunes replaces characters in row using a mapping
+func replaceRunes(row []rune, mapping map[rune]rune) {
+	for i, ch := range row {
+		if r, ok := mapping[ch]; ok {
+			row[i] = r
+		}
+	}
+}
+
+// SetTransform changes the transforms applied to every glyph
+func (bf *BlockFont) SetTransform(t Transform) {
+	bf.config.Transform = t
+}
@@ -0,0 +1,127 @@
+package gofig
+
+import "unicode/utf8"
+
+// Spacing is a number of empty cells around rendered text:
+// columns on the left and right, rows on the top and bottom
+type Spacing struct {
+	Top, Right, Bottom, Left int
+}
+
+// Uniform returns the same spacing on all four sides
+func Uniform(n int) Spacing {
+	return Spacing{Top: n, Right: n, Bottom: n, Left: n}
+}
+
+// Center selects the directions text is centered in the viewport
+type Center int
+
+// CenterNone keeps text at the top left
+const CenterNone Center = 0
+
+const (
+	// CenterHorizontal centers text between the left and right edges
+	CenterHorizontal Center = 1 << iota
+	// CenterVertical centers text between the top and bottom edges
+	CenterVertical
+
+	// CenterBoth centers text in both directions
+	CenterBoth = CenterHorizontal | CenterVertical
+)
+
+// place surrounds the output with padding, the border and margins and
+// centers it in the viewport. Padding takes the colors of empty cells,
+// margins have none.
+func (bf *BlockFont) place(screen [][]termCell) [][]termCell {
+	empty := termCell{
+		text:  bf.config.Space,
+		style: Style{Foreground: bf.config.SpaceColor, Background: bf.config.SpaceBackground},
+	}
+	screen = bf.frame(surround(screen, bf.config.Padding, empty))
+
+	margin := bf.config.Margin
+	if bf.config.Center != CenterNone {
+		viewWidth, viewHeight := bf.config.ViewWidth, bf.config.ViewHeight
+		if viewWidth <= 0 || viewHeight <= 0 {
+			termWidth, termHeight := TerminalSize()
+			if viewWidth <= 0 {
+				viewWidth = termWidth
+			}
+			if viewHeight <= 0 {
+				viewHeight = termHeight
+			}
+		}
+
+		width := margin.Left + margin.Right
+		if len(screen) > 0 {
+			width += columns(screen[0])
+		}
+		height := margin.Top + margin.Bottom + len(screen)
+		if bf.config.Center&CenterHorizontal != 0 && viewWidth > width {
+			margin.Left += (viewWidth - width) / 2
+		}
+		if bf.config.Center&CenterVertical != 0 && viewHeight > height {
+			margin.Top += (viewHeight - height) / 2
+		}
+	}
+	return surround(screen, margin, termCell{text: " "})
+}
+
+// surround adds rows and columns of fill cells around screen
+func surround(screen [][]termCell, spacing Spacing, fill termCell) [][]termCell {
+	if spacing == (Spacing{}) {
+		return screen
+	}
+	width := 0
+	if len(screen) > 0 {
+		width = len(screen[0])
+	}
+	fillRow := func(n int) []termCell {
+		row := make([]termCell, max(n, 0))
+		for i := range row {
+			row[i] = fill
+		}
+		return row
+	}
+
+	total := spacing.Left + width + spacing.Right
+	result := make([][]termCell, 0, spacing.Top+len(screen)+spacing.Bottom)
+	for i := 0; i < spacing.Top; i++ {
+		result = append(result, fillRow(total))
+	}
+	for _, row := range screen {
+		line := append(fillRow(spacing.Left), row...)
+		result = append(result, append(line, fillRow(spacing.Right)...))
+	}
+	for i := 0; i < spacing.Bottom; i++ {
+		result = append(result, fillRow(total))
+	}
+	return result
+}
+
+// columns returns the number of terminal columns a row of output takes
+func columns(row []termCell) int {
+	n := 0
+	for _, c := range row {
+		n += utf8.RuneCountInString(c.text)
+	}
+	return n
+}
+
+// SetPadding sets the space between the text and the edge of its panel
+func (bf *BlockFont) SetPadding(padding Spacing) {
+	bf.config.Padding = padding
+}
+
+// SetMargin sets the space around the panel
+func (bf *BlockFont) SetMargin(margin Spacing) {
+	bf.config.Margin = margin
+}
+
+// SetCenter centers output in a viewport of the given size
+// (0 = the size of the terminal)
+func (bf *BlockFont) SetCenter(center Center, width, height int) {
+	bf.config.Center = center
+	bf.config.ViewWidth = width
+	bf.config.ViewHeight = height
+}
@@ -0,0 +1,188 @@
+package gofig
+
+import "unicode/utf8"
+
+// MaxWidthTerminal as Config.MaxWidth wraps text to the terminal width
+const MaxWidthTerminal = -1
+
+// textLine is one line of output text
+type textLine struct {
+	runes []rune
+	// first is the index of the first letter in the whole text
+	first int
+	// hyphen adds '-' after a word broken across lines
+	hyphen bool
+}
+
+// splitLines splits text on newlines and wraps lines wider than
+// Config.MaxWidth. Vertical text puts every letter on its own line; a
+// newline there becomes an empty line, like an empty line of horizontal text.
+func (bf *BlockFont) splitLines(runes []rune) []textLine {
+	if bf.config.Orientation == OrientationVertical {
+		lines := make([]textLine, 0, len(runes))
+		for i := range runes {
+			switch runes[i] {
+			case '\r':
+			case '\n':
+				lines = append(lines, textLine{first: i})
+			default:
+				lines = append(lines, textLine{runes: runes[i : i+1], first: i})
+			}
+		}
+		return lines
+	}
+
+	limit := bf.wrapLimit()
+	var lines []textLine
+	start := 0
+	for i := 0; i <= len(runes); i++ {
+		if i < len(runes) && runes[i] != '\n' {
+			continue
+		}
+		end := i
+		if end > start && runes[end-1] == '\r' {
+			end--
+		}
+		line := textLine{runes: runes[start:end], first: start}
+		if limit > 0 {
+			lines = append(lines, bf.wrapLine(line, limit)...)
+		} else {
+			lines = append(lines, line)
+		}
+		start = i + 1
+	}
+	return lines
+}
+
+// wrapLimit returns the widest line allowed by Config.MaxWidth in glyph
+// columns, 0 if lines are not wrapped. Lines that do not run across the
+// output, as in vertical or rotated text, are never wrapped.
+func (bf *BlockFont) wrapLimit() int {
+	maxWidth := bf.config.MaxWidth
+	if bf.config.Orientation.rotated() {
+		return 0
+	}
+	if maxWidth == MaxWidthTerminal {
+		maxWidth, _ = TerminalSize()
+	}
+	if maxWidth <= 0 {
+		return 0
+	}
+
+	// Padding, the border and margins take part of the width
+	spaceWidth := max(utf8.RuneCountInString(bf.config.Space), 1)
+	padding, margin := bf.config.Padding, bf.config.Margin
+	maxWidth -= (padding.Left+padding.Right)*spaceWidth + bf.borderWidth() + margin.Left + margin.Right
+
+	// Terminal columns taken by one glyph column
+	cw, _ := bf.config.Mode.cellSize()
+	charWidth := 1
+	if bf.config.Mode == ModeBlock {
+		charWidth = max(utf8.RuneCountInString(bf.config.Char), utf8.RuneCountInString(bf.shadowChar()),
+			utf8.RuneCountInString(bf.hollowChar()), spaceWidth)
+	}
+
+	// The shadow makes the text wider
+	shadowWidth, _ := bf.config.Shadow.extent()
+	scaleX, _ := bf.scaleFactors()
+	return max(maxWidth*cw/(scaleX*charWidth)-shadowWidth, 1)
+}
+
+// wrapLine breaks a line at spaces so that every part fits limit columns.
+// Words wider than limit are broken, with a hyphen if Config.Hyphenate is set.
+func (bf *BlockFont) wrapLine(line textLine, limit int) []textLine {
+	var wrapped []textLine
+	runes := line.runes
+	pos := 0
+	for {
+		// Spaces at a line break are dropped
+		if len(wrapped) > 0 {
+			for pos < len(runes) && runes[pos] == ' ' {
+				pos++
+			}
+		}
+		rest := runes[pos:]
+		if len(rest) == 0 && len(wrapped) > 0 {
+			return wrapped
+		}
+		fit := bf.fitLength(rest, limit)
+		if fit == len(rest) {
+			return append(wrapped, textLine{runes: rest, first: line.first + pos})
+		}
+
+		// Longest run of whole words that fits
+		end := 0
+		for i := min(fit, len(rest)-1); i > 0; i-- {
+			if rest[i] == ' ' && rest[i-1] != ' ' {
+				end = i
+				break
+			}
+		}
+		if end > 0 {
+			wrapped = append(wrapped, textLine{runes: rest[:end], first: line.first + pos})
+			pos += end
+			continue
+		}
+
+		// The first word alone is too wide: break it, keeping any spaces
+		// before it unless they fill the line by themselves
+		wordStart := 0
+		for wordStart < len(rest) && rest[wordStart] == ' ' {
+			wordStart++
+		}
+		if fit <= wordStart {
+			pos += wordStart
+			continue
+		}
+		wordEnd := wordStart
+		for wordEnd < len(rest) && rest[wordEnd] != ' ' {
+			wordEnd++
+		}
+		n := max(min(fit, wordEnd-1), wordStart+1)
+		hyphen := bf.config.Hyphenate && n < wordEnd
+		for hyphen && n > wordStart+1 && bf.lineWidth(rest[:n], true) > limit {
+			n--
+		}
+		if hyphen && bf.lineWidth(rest[:n], true) > limit {
+			hyphen = false
+		}
+		wrapped = append(wrapped, textLine{runes: rest[:n], first: line.first + pos, hyphen: hyphen})
+		pos += n
+	}
+}
+
+// fitLength returns how many leading runes fit limit glyph columns.
+// Layout stops at the first rune that overflows; lines never get narrower
+// as letters are added, so the rest cannot fit either.
+func (bf *BlockFont) fitLength(runes []rune, limit int) int {
+	l := bf.newLineLayout()
+	for i, ch := range runes {
+		l.add(newGlyph(bf.pattern(ch), i))
+		if l.width() > limit {
+			return i
+		}
+	}
+	return len(runes)
+}
+
+// lineWidth returns the width of a laid out line in glyph columns
+func (bf *BlockFont) lineWidth(runes []rune, hyphen bool) int {
+	glyphs := make([]glyph, 0, len(runes)+1)
+	for i, ch := range runes {
+		glyphs = append(glyphs, newGlyph(bf.pattern(ch), i))
+	}
+	if hyphen {
+		glyphs = append(glyphs, newGlyph(bf.pattern('-'), -1))
+	}
+	width := 0
+	for i, offset := range bf.layoutLine(glyphs) {
+		width = max(width, offset+glyphs[i].width())
+	}
+	return width
+}
+
+// SetMaxWidth sets the terminal width text is wrapped to
+// (0 = no wrapping, MaxWidthTerminal = the current terminal)
+func (bf *BlockFont) SetMaxWidth(width int) {
+	bf.config.MaxWidth = width
+}
@@ -0,0 +1,34 @@
+package gofig
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWrapLeadingSpaces(t *testing.T) {
+	for _, hyphenate := range []bool{true, false} {
+		config := DefaultConfig()
+		config.MaxWidth = 20
+		config.Hyphenate = hyphenate
+		bf := NewWithConfig(config)
+
+		lines := bf.splitLines([]rune("   ABCDEFGH"))
+		var text strings.Builder
+		for i, line := range lines {
+			s := string(line.runes)
+			if strings.TrimSpace(s) == "" {
+				t.Errorf("hyphenate %v: line %d is blank", hyphenate, i)
+			}
+			if line.hyphen && strings.HasSuffix(s, " ") {
+				t.Errorf("hyphenate %v: line %d %q is hyphenated after a space", hyphenate, i, s)
+			}
+			if w := bf.lineWidth(line.runes, line.hyphen); w > 20 {
+				t.Errorf("hyphenate %v: line %d %q is %d wide", hyphenate, i, s, w)
+			}
+			text.WriteString(s)
+		}
+		if got := text.String(); got != "   ABCDEFGH" {
+			t.Errorf("hyphenate %v: lines join to %q", hyphenate, got)
+		}
+	}
+}